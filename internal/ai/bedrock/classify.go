@@ -0,0 +1,95 @@
+package bedrock
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	brtypes "github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/aws/smithy-go"
+)
+
+// ErrorClass tells whether a failed call may succeed when retried.
+type ErrorClass int
+
+const (
+	// Permanent errors, such as validation or access errors, are not retried.
+	Permanent ErrorClass = iota
+	Throttling
+	Timeout
+	ServerError
+)
+
+func (c ErrorClass) String() string {
+	switch c {
+	case Throttling:
+		return "throttling"
+	case Timeout:
+		return "timeout"
+	case ServerError:
+		return "server error"
+	default:
+		return "permanent"
+	}
+}
+
+// Classify sorts a Bedrock error into an ErrorClass. Errors of other model
+// drivers are classified by their HTTP status when they expose one.
+func Classify(err error) ErrorClass {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return Permanent
+	}
+
+	var (
+		throttling   *brtypes.ThrottlingException
+		modelTimeout *brtypes.ModelTimeoutException
+		notReady     *brtypes.ModelNotReadyException
+		internal     *brtypes.InternalServerException
+		unavailable  *brtypes.ServiceUnavailableException
+	)
+	switch {
+	case errors.As(err, &throttling):
+		return Throttling
+	case errors.As(err, &modelTimeout):
+		return Timeout
+	case errors.As(err, &notReady), errors.As(err, &internal), errors.As(err, &unavailable):
+		return ServerError
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "ThrottlingException", "TooManyRequestsException", "RequestLimitExceeded":
+			return Throttling
+		}
+	}
+
+	// Matches *awshttp.ResponseError as well as the errors of HTTP drivers
+	// outside the SDK.
+	var respErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &respErr) {
+		switch status := respErr.HTTPStatusCode(); {
+		case status == 429:
+			return Throttling
+		case status == 408:
+			return Timeout
+		case status >= 500:
+			return ServerError
+		}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return Timeout
+	}
+	// Connection failures mean the endpoint is unreachable right now.
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return ServerError
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return Timeout
+	}
+
+	return Permanent
+}
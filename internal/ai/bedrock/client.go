@@ -0,0 +1,174 @@
+// Package bedrock wraps the Bedrock runtime client shared by the AI drivers
+// with classified retries, jittered exponential backoff, a per-model token
+// bucket and a per-model concurrency cap.
+package bedrock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	bedrockruntime "github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+)
+
+const (
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = 250 * time.Millisecond
+	defaultMaxBackoff     = 20 * time.Second
+)
+
+// Options configure retries and client-side limits. Limits are shared by
+// all clients of the same model ID with the same limit options in the
+// process.
+type Options struct {
+	// MaxAttempts includes the first call. Defaults to 5.
+	MaxAttempts    int           `yaml:"maxAttempts"`
+	InitialBackoff time.Duration `yaml:"initialBackoff"`
+	MaxBackoff     time.Duration `yaml:"maxBackoff"`
+	// RequestsPerSecond enables the token bucket; Burst defaults to 1.
+	RequestsPerSecond float64 `yaml:"requestsPerSecond"`
+	Burst             int     `yaml:"burst"`
+	MaxConcurrency    int     `yaml:"maxConcurrency"`
+}
+
+func (o *Options) Validate() error {
+	var errs []error
+	if o.MaxAttempts < 0 {
+		errs = append(errs, fmt.Errorf("maxAttempts must be positive, got %d", o.MaxAttempts))
+	}
+	if o.InitialBackoff < 0 || o.MaxBackoff < 0 {
+		errs = append(errs, fmt.Errorf("backoff durations must be positive"))
+	}
+	if o.InitialBackoff > 0 && o.MaxBackoff > 0 && o.InitialBackoff > o.MaxBackoff {
+		errs = append(errs, fmt.Errorf("initialBackoff %s exceeds maxBackoff %s", o.InitialBackoff, o.MaxBackoff))
+	}
+	if o.RequestsPerSecond < 0 {
+		errs = append(errs, fmt.Errorf("requestsPerSecond must be positive, got %v", o.RequestsPerSecond))
+	}
+	if o.Burst < 0 {
+		errs = append(errs, fmt.Errorf("burst must be positive, got %d", o.Burst))
+	}
+	if o.MaxConcurrency < 0 {
+		errs = append(errs, fmt.Errorf("maxConcurrency must be positive, got %d", o.MaxConcurrency))
+	}
+	return errors.Join(errs...)
+}
+
+func (o Options) withDefaults() Options {
+	if o.MaxAttempts == 0 {
+		o.MaxAttempts = defaultMaxAttempts
+	}
+	if o.InitialBackoff == 0 {
+		o.InitialBackoff = defaultInitialBackoff
+	}
+	if o.MaxBackoff == 0 {
+		o.MaxBackoff = defaultMaxBackoff
+	}
+	if o.RequestsPerSecond > 0 && o.Burst == 0 {
+		o.Burst = 1
+	}
+	return o
+}
+
+// Client exposes the Bedrock runtime calls used by the drivers.
+type Client struct {
+	runtime *bedrockruntime.Client
+	opts    Options
+}
+
+// New builds a client from an AWS config; optFns customize the runtime
+// client, e.g. its endpoint. The SDK retryer is disabled so that attempts are
+// only counted here.
+func New(acfg aws.Config, opts Options, optFns ...func(*bedrockruntime.Options)) *Client {
+	optFns = append([]func(*bedrockruntime.Options){func(o *bedrockruntime.Options) {
+		o.Retryer = aws.NopRetryer{}
+	}}, optFns...)
+	return &Client{
+		runtime: bedrockruntime.NewFromConfig(acfg, optFns...),
+		opts:    opts.withDefaults(),
+	}
+}
+
+func (c *Client) InvokeModel(
+	ctx context.Context,
+	params *bedrockruntime.InvokeModelInput,
+	optFns ...func(*bedrockruntime.Options),
+) (*bedrockruntime.InvokeModelOutput, error) {
+	var out *bedrockruntime.InvokeModelOutput
+	err := c.do(ctx, aws.ToString(params.ModelId), func(ctx context.Context) error {
+		var err error
+		out, err = c.runtime.InvokeModel(ctx, params, optFns...)
+		return err
+	})
+	return out, err
+}
+
+// InvokeModelWithResponseStream retries opening the stream. Errors in the
+// middle of a stream are reported by the stream and not retried, and the
+// concurrency slot is released once the stream is open.
+func (c *Client) InvokeModelWithResponseStream(
+	ctx context.Context,
+	params *bedrockruntime.InvokeModelWithResponseStreamInput,
+	optFns ...func(*bedrockruntime.Options),
+) (*bedrockruntime.InvokeModelWithResponseStreamOutput, error) {
+	var out *bedrockruntime.InvokeModelWithResponseStreamOutput
+	err := c.do(ctx, aws.ToString(params.ModelId), func(ctx context.Context) error {
+		var err error
+		out, err = c.runtime.InvokeModelWithResponseStream(ctx, params, optFns...)
+		return err
+	})
+	return out, err
+}
+
+func (c *Client) do(ctx context.Context, modelID string, call func(context.Context) error) error {
+	l := limitsFor(modelID, c.opts)
+
+	var err error
+	for attempt := 0; attempt < c.opts.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			t := time.NewTimer(c.backoff(attempt))
+			select {
+			case <-t.C:
+			case <-ctx.Done():
+				t.Stop()
+				return fmt.Errorf("%w (last error: %v)", ctx.Err(), err)
+			}
+		}
+
+		release, lerr := l.acquire(ctx)
+		if lerr != nil {
+			if err != nil {
+				return fmt.Errorf("%w (last error: %v)", lerr, err)
+			}
+			return lerr
+		}
+		err = call(ctx)
+		release()
+
+		if err == nil {
+			return nil
+		}
+		// A cancelled or expired caller context is not worth retrying.
+		if ctx.Err() != nil || Classify(err) == Permanent {
+			return err
+		}
+	}
+
+	return fmt.Errorf("bedrock call failed after %d attempts: %w", c.opts.MaxAttempts, err)
+}
+
+// backoff returns a full-jitter delay: a random duration up to the
+// exponential backoff of the attempt.
+func (c *Client) backoff(attempt int) time.Duration {
+	d := c.opts.MaxBackoff
+	if attempt <= 32 {
+		d = c.opts.InitialBackoff << (attempt - 1)
+	}
+	if d <= 0 || d > c.opts.MaxBackoff {
+		d = c.opts.MaxBackoff
+	}
+	return rand.N(d) + 1
+}
@@ -0,0 +1,117 @@
+package bedrock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// bucket is a token bucket refilled at rate tokens per second.
+type bucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(rate float64, burst int) *bucket {
+	return &bucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+func (b *bucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		t := time.NewTimer(delay)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// limitKey identifies the clients sharing limits: the same model with the
+// same limit options.
+type limitKey struct {
+	modelID     string
+	rate        float64
+	burst       int
+	concurrency int
+}
+
+// limits are shared by every client calling the same model with the same
+// options.
+type limits struct {
+	bucket *bucket
+	sem    chan struct{}
+}
+
+var (
+	limitsMu sync.Mutex
+	byModel  = map[limitKey]*limits{}
+)
+
+// limitsFor returns the limits of a model for opts. Clients configured with
+// different options, e.g. before and after a configuration reload, get
+// separate limits instead of resetting each other's.
+func limitsFor(modelID string, opts Options) *limits {
+	limitsMu.Lock()
+	defer limitsMu.Unlock()
+
+	key := limitKey{
+		modelID:     modelID,
+		rate:        opts.RequestsPerSecond,
+		burst:       opts.Burst,
+		concurrency: opts.MaxConcurrency,
+	}
+	if l, ok := byModel[key]; ok {
+		return l
+	}
+
+	l := &limits{}
+	if opts.RequestsPerSecond > 0 {
+		l.bucket = newBucket(opts.RequestsPerSecond, opts.Burst)
+	}
+	if opts.MaxConcurrency > 0 {
+		l.sem = make(chan struct{}, opts.MaxConcurrency)
+	}
+	byModel[key] = l
+	return l
+}
+
+// acquire waits for a rate token and a concurrency slot. The returned
+// function releases the slot.
+func (l *limits) acquire(ctx context.Context) (func(), error) {
+	if l.bucket != nil {
+		if err := l.bucket.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if l.sem == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.sem <- struct{}{}:
+		return func() { <-l.sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,26 @@
+package bedrock
+
+import (
+	"strconv"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// TokenCounts reads the token counts Bedrock reports in the InvokeModel
+// response headers, for models whose response body has no usage section.
+func TokenCounts(md middleware.Metadata) (input, output int, ok bool) {
+	resp, isHTTP := awsmiddleware.GetRawResponse(md).(*smithyhttp.Response)
+	if !isHTTP {
+		return 0, 0, false
+	}
+
+	in, err := strconv.Atoi(resp.Header.Get("X-Amzn-Bedrock-Input-Token-Count"))
+	if err != nil {
+		return 0, 0, false
+	}
+	// Embedding and rerank responses have no output tokens.
+	out, _ := strconv.Atoi(resp.Header.Get("X-Amzn-Bedrock-Output-Token-Count"))
+	return in, out, true
+}
@@ -3,28 +3,74 @@ package cohereembedding
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"sync"
 
-	awsCfg "github.com/aws/aws-sdk-go-v2/config"
 	bedrockruntime "github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/bexprt/bexgen-client/internal/ai/bedrock"
+	"github.com/bexprt/bexgen-client/internal/awsconfig"
 	"github.com/bexprt/bexgen-client/pkg/ai/types"
+	"github.com/bexprt/bexgen-client/pkg/ai/usage"
 	"github.com/bexprt/bexgen-client/pkg/config"
 )
 
-type EmbeddingType string
+type EmbeddingType = types.EmbeddingType
 
 const (
-	EmbeddingFloat  EmbeddingType = "float"
-	EmbeddingInt8   EmbeddingType = "int8"
-	EmbeddingUint8  EmbeddingType = "uint8"
-	EmbeddingBinary EmbeddingType = "binary"
-	EmbeddingUBin   EmbeddingType = "ubinary"
+	EmbeddingFloat  = types.EmbeddingFloat
+	EmbeddingInt8   = types.EmbeddingInt8
+	EmbeddingUint8  = types.EmbeddingUint8
+	EmbeddingBinary = types.EmbeddingBinary
+	EmbeddingUBin   = types.EmbeddingUBinary
+)
+
+// Cohere accepts at most 96 texts per request. The byte budget keeps the
+// request body well below the Bedrock payload limit.
+const (
+	maxBatchSize         = 96
+	defaultMaxBatchBytes = 512 * 1024
+	defaultConcurrency   = 4
 )
 
 type BedrockCohereEmbedder struct {
-	client    *bedrockruntime.Client
-	dimension int
-	modelID   string
+	client        *bedrock.Client
+	dimension     int
+	modelID       string
+	batchSize     int
+	maxBatchBytes int
+	concurrency   int
+}
+
+type Options struct {
+	ModelID       string `yaml:"modelId" required:"true"`
+	Dimension     int    `yaml:"dimension"`
+	BatchSize     int    `yaml:"batchSize"`
+	MaxBatchBytes int    `yaml:"maxBatchBytes"`
+	Concurrency   int    `yaml:"concurrency"`
+	// Bedrock configures retries and rate limits.
+	Bedrock bedrock.Options `yaml:"bedrock"`
+	// AWS selects the region, credentials and endpoint of the Bedrock
+	// client.
+	AWS awsconfig.Options `yaml:"aws"`
+}
+
+func (o *Options) Validate() error {
+	var errs []error
+	if o.Dimension < 0 {
+		errs = append(errs, fmt.Errorf("dimension must be positive, got %d", o.Dimension))
+	}
+	if o.BatchSize < 0 || o.BatchSize > maxBatchSize {
+		errs = append(errs, fmt.Errorf("batchSize must be between 1 and %d, got %d", maxBatchSize, o.BatchSize))
+	}
+	if o.MaxBatchBytes < 0 {
+		errs = append(errs, fmt.Errorf("maxBatchBytes must be positive, got %d", o.MaxBatchBytes))
+	}
+	if o.Concurrency < 0 {
+		errs = append(errs, fmt.Errorf("concurrency must be positive, got %d", o.Concurrency))
+	}
+	return errors.Join(errs...)
 }
 
 type CohereEmbedRequest struct {
@@ -42,8 +88,8 @@ type CohereEmbedResponse struct {
 		Float   [][]float32 `json:"float,omitempty"`
 		Int8    [][]int8    `json:"int8,omitempty"`
 		Uint8   [][]uint8   `json:"uint8,omitempty"`
-		Binary  []string    `json:"binary,omitempty"`
-		UBinary []string    `json:"ubinary,omitempty"`
+		Binary  [][]int8    `json:"binary,omitempty"`
+		UBinary [][]uint8   `json:"ubinary,omitempty"`
 	} `json:"embeddings"`
 	ResponseType string   `json:"response_type"`
 	Texts        []string `json:"texts,omitempty"`
@@ -51,21 +97,40 @@ type CohereEmbedResponse struct {
 
 func NewBedrockCohereEmbedder(
 	ctx context.Context,
-	modelID string,
 	cfg *config.FactoryConfig,
 ) (types.Embedder, error) {
-	acfg, err := awsCfg.LoadDefaultConfig(ctx)
+	opts := &Options{}
+	if err := cfg.DecodeOptions(opts); err != nil {
+		return nil, fmt.Errorf("invalid embedding configuration: %w", err)
+	}
+
+	acfg, err := awsconfig.Load(ctx, opts.AWS)
 	if err != nil {
-		return nil, fmt.Errorf("aws config load failed: %w", err)
+		return nil, err
 	}
 
+	client := bedrock.New(acfg, opts.Bedrock, func(o *bedrockruntime.Options) {
+		o.BaseEndpoint = opts.AWS.BaseEndpoint()
+	})
 	embedder := &BedrockCohereEmbedder{
-		client:  bedrockruntime.NewFromConfig(acfg),
-		modelID: modelID,
+		client:        client,
+		modelID:       opts.ModelID,
+		batchSize:     maxBatchSize,
+		maxBatchBytes: defaultMaxBatchBytes,
+		concurrency:   defaultConcurrency,
+	}
+	if opts.BatchSize > 0 {
+		embedder.batchSize = opts.BatchSize
+	}
+	if opts.MaxBatchBytes > 0 {
+		embedder.maxBatchBytes = opts.MaxBatchBytes
+	}
+	if opts.Concurrency > 0 {
+		embedder.concurrency = opts.Concurrency
 	}
 
-	if dim, ok := cfg.Options["dimension"].(int); ok {
-		embedder.dimension = dim
+	if opts.Dimension > 0 {
+		embedder.dimension = opts.Dimension
 	} else {
 		embedder.dimension = 1024
 	}
@@ -98,10 +163,125 @@ func (e *BedrockCohereEmbedder) Embed(
 	texts []string,
 	embedType types.EmbeddingInputType,
 ) ([][]float32, error) {
+	out, err := e.EmbedAs(ctx, texts, embedType, EmbeddingFloat)
+	if out == nil {
+		return nil, err
+	}
+	return out.Float, err
+}
+
+// EmbedAs splits texts into batches that fit the model limits and embeds them
+// concurrently.
+func (e *BedrockCohereEmbedder) EmbedAs(
+	ctx context.Context,
+	texts []string,
+	embedType types.EmbeddingInputType,
+	as EmbeddingType,
+) (*types.Embeddings, error) {
+	out, err := types.NewEmbeddings(as, len(texts))
+	if err != nil {
+		return nil, err
+	}
+	if len(texts) == 0 {
+		return out, nil
+	}
+
+	batches := e.batches(texts)
+	if len(batches) == 1 {
+		return e.embedBatch(ctx, texts, embedType, as)
+	}
+
+	var (
+		sem      = make(chan struct{}, e.concurrency)
+		mu       sync.Mutex
+		failures []types.BatchFailure
+		wg       sync.WaitGroup
+	)
+
+	for _, b := range batches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var (
+				result *types.Embeddings
+				err    error
+			)
+			select {
+			case sem <- struct{}{}:
+				result, err = e.embedBatch(ctx, texts[b.start:b.end], embedType, as)
+				<-sem
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+
+			if err != nil {
+				mu.Lock()
+				failures = append(failures, types.BatchFailure{Indices: b.indices(), Err: err})
+				mu.Unlock()
+				return
+			}
+			out.CopyFrom(b.start, result)
+		}()
+	}
+	wg.Wait()
+
+	if len(failures) == len(batches) {
+		return nil, failures[0].Err
+	}
+	if len(failures) > 0 {
+		sort.Slice(failures, func(i, j int) bool {
+			return failures[i].Indices[0] < failures[j].Indices[0]
+		})
+		return out, &types.BatchError{Total: len(texts), Failures: failures}
+	}
+
+	return out, nil
+}
+
+type batch struct {
+	start, end int
+}
+
+func (b batch) indices() []int {
+	indices := make([]int, 0, b.end-b.start)
+	for i := b.start; i < b.end; i++ {
+		indices = append(indices, i)
+	}
+	return indices
+}
+
+// batches groups consecutive texts by count and size. A text larger than the
+// byte budget gets a batch of its own and is left to the model's truncation.
+func (e *BedrockCohereEmbedder) batches(texts []string) []batch {
+	var (
+		batches []batch
+		cur     batch
+		size    int
+	)
+	for i, t := range texts {
+		n := cur.end - cur.start
+		if n > 0 && (n == e.batchSize || size+len(t) > e.maxBatchBytes) {
+			batches = append(batches, cur)
+			cur = batch{start: i, end: i}
+			size = 0
+		}
+		cur.end = i + 1
+		size += len(t)
+	}
+	return append(batches, cur)
+}
+
+func (e *BedrockCohereEmbedder) embedBatch(
+	ctx context.Context,
+	texts []string,
+	embedType types.EmbeddingInputType,
+	as EmbeddingType,
+) (*types.Embeddings, error) {
 	req := &CohereEmbedRequest{
 		InputType:      embedType,
 		Texts:          texts,
-		EmbeddingTypes: []EmbeddingType{EmbeddingFloat},
+		EmbeddingTypes: []EmbeddingType{as},
 		OutputDim:      e.dimension,
 	}
 
@@ -114,19 +294,38 @@ func (e *BedrockCohereEmbedder) Embed(
 	if err != nil {
 		return nil, fmt.Errorf("bedrock invoke failed: %w", err)
 	}
+	if in, _, ok := bedrock.TokenCounts(result.ResultMetadata); ok {
+		usage.Report(ctx, usage.Record{ModelID: e.modelID, Operation: usage.OperationEmbed, InputTokens: in})
+	}
 
 	var response CohereEmbedResponse
 	if err := json.Unmarshal(result.Body, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	if len(response.Embeddings.Float) == 0 {
+	out := &types.Embeddings{
+		Type:    as,
+		Float:   response.Embeddings.Float,
+		Int8:    response.Embeddings.Int8,
+		Uint8:   response.Embeddings.Uint8,
+		Binary:  response.Embeddings.Binary,
+		UBinary: response.Embeddings.UBinary,
+	}
+
+	if out.Len() == 0 {
 		return nil, fmt.Errorf("no embeddings returned from model")
 	}
+	if out.Len() != len(texts) {
+		return nil, fmt.Errorf("model returned %d embeddings for %d texts", out.Len(), len(texts))
+	}
 
-	return response.Embeddings.Float, nil
+	return out, nil
 }
 
 func (e *BedrockCohereEmbedder) Dimension() int {
 	return e.dimension
 }
+
+func (e *BedrockCohereEmbedder) ModelID() string {
+	return e.modelID
+}
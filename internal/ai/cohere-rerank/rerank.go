@@ -2,36 +2,151 @@ package coherererank
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
 
-	awsCfg "github.com/aws/aws-sdk-go-v2/config"
 	bedrockruntime "github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/bexprt/bexgen-client/internal/ai/bedrock"
+	"github.com/bexprt/bexgen-client/internal/awsconfig"
 	"github.com/bexprt/bexgen-client/pkg/ai/types"
+	"github.com/bexprt/bexgen-client/pkg/ai/usage"
 	"github.com/bexprt/bexgen-client/pkg/config"
 )
 
+// maxDocuments is the number of documents Cohere accepts per request.
+const maxDocuments = 1000
+
 type CohereClient struct {
-	client      *bedrockruntime.Client
-	modelID     string
-	MaxTokens   int
-	Temperature int
+	client  *bedrock.Client
+	modelID string
+	topN    int
+}
+
+type Options struct {
+	ModelID string `yaml:"modelId" required:"true"`
+	TopN    int    `yaml:"topN"`
+	// Bedrock configures retries and rate limits.
+	Bedrock bedrock.Options `yaml:"bedrock"`
+	// AWS selects the region, credentials and endpoint of the Bedrock
+	// client.
+	AWS awsconfig.Options `yaml:"aws"`
+}
+
+func (o *Options) Validate() error {
+	if o.TopN < 0 {
+		return fmt.Errorf("topN must be positive, got %d", o.TopN)
+	}
+	return nil
+}
+
+type CohereRerankRequest struct {
+	Query      string   `json:"query"`
+	Documents  []string `json:"documents"`
+	TopN       int      `json:"top_n,omitempty"`
+	APIVersion int      `json:"api_version"`
 }
 
-func New(ctx context.Context, modelID string, cfg *config.FactoryConfig) (types.Rerank, error) {
-	acfg, err := awsCfg.LoadDefaultConfig(ctx)
+type CohereRerankResponse struct {
+	ID      string `json:"id"`
+	Results []struct {
+		Index          int     `json:"index"`
+		RelevanceScore float32 `json:"relevance_score"`
+	} `json:"results"`
+}
+
+func New(ctx context.Context, cfg *config.FactoryConfig) (types.Rerank, error) {
+	opts := &Options{}
+	if err := cfg.DecodeOptions(opts); err != nil {
+		return nil, fmt.Errorf("invalid rerank configuration: %w", err)
+	}
+
+	acfg, err := awsconfig.Load(ctx, opts.AWS)
 	if err != nil {
-		return nil, fmt.Errorf("aws config load failed: %w", err)
+		return nil, err
 	}
 
+	client := bedrock.New(acfg, opts.Bedrock, func(o *bedrockruntime.Options) {
+		o.BaseEndpoint = opts.AWS.BaseEndpoint()
+	})
 	rerank := &CohereClient{
-		client:  bedrockruntime.NewFromConfig(acfg),
-		modelID: modelID,
+		client:  client,
+		modelID: opts.ModelID,
+		topN:    opts.TopN,
 	}
 
 	return rerank, nil
 }
 
-// TODO: implement Rank
-func (c CohereClient) Rerank(ctx context.Context, texts []string) ([]string, error) {
-	return []string{}, nil
+func (c *CohereClient) Rerank(
+	ctx context.Context,
+	query string,
+	documents []string,
+	opts *types.RerankOptions,
+) ([]types.RerankResult, error) {
+	if query == "" {
+		return nil, fmt.Errorf("query cannot be empty")
+	}
+	if len(documents) == 0 {
+		return []types.RerankResult{}, nil
+	}
+	if len(documents) > maxDocuments {
+		return nil, fmt.Errorf("too many documents: %d, max %d", len(documents), maxDocuments)
+	}
+
+	topN := c.topN
+	if opts != nil && opts.TopN > 0 {
+		topN = opts.TopN
+	}
+	if topN > len(documents) {
+		topN = len(documents)
+	}
+
+	payload, err := json.Marshal(&CohereRerankRequest{
+		Query:      query,
+		Documents:  documents,
+		TopN:       topN,
+		APIVersion: 2,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	contentType := "application/json"
+	accept := "application/json"
+
+	out, err := c.client.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
+		ModelId:     &c.modelID,
+		ContentType: &contentType,
+		Accept:      &accept,
+		Body:        payload,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bedrock invoke failed: %w", err)
+	}
+	if in, _, ok := bedrock.TokenCounts(out.ResultMetadata); ok {
+		usage.Report(ctx, usage.Record{ModelID: c.modelID, Operation: usage.OperationRerank, InputTokens: in})
+	}
+
+	var response CohereRerankResponse
+	if err := json.Unmarshal(out.Body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	results := make([]types.RerankResult, 0, len(response.Results))
+	for _, r := range response.Results {
+		if r.Index < 0 || r.Index >= len(documents) {
+			return nil, fmt.Errorf("rerank returned out of range index %d", r.Index)
+		}
+		results = append(results, types.RerankResult{
+			Index: r.Index,
+			Score: r.RelevanceScore,
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+
+	return results, nil
 }
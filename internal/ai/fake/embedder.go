@@ -0,0 +1,178 @@
+// Package fake provides deterministic, offline AI drivers for tests and local
+// development.
+package fake
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"unicode"
+
+	"github.com/bexprt/bexgen-client/pkg/ai/types"
+	"github.com/bexprt/bexgen-client/pkg/config"
+)
+
+const defaultDimension = 1024
+
+type EmbedderOptions struct {
+	Dimension int `yaml:"dimension"`
+}
+
+func (o *EmbedderOptions) Validate() error {
+	if o.Dimension < 0 || o.Dimension%8 != 0 {
+		return fmt.Errorf("dimension must be a positive multiple of 8, got %d", o.Dimension)
+	}
+	return nil
+}
+
+// Embedder derives vectors from the words of a text: each word maps to a
+// hash-seeded random vector and a text embeds as their normalised sum, so
+// identical texts get identical vectors and texts sharing words are similar.
+type Embedder struct {
+	dimension int
+}
+
+func NewEmbedder(ctx context.Context, cfg *config.FactoryConfig) (types.Embedder, error) {
+	opts := &EmbedderOptions{}
+	if err := cfg.DecodeOptions(opts); err != nil {
+		return nil, fmt.Errorf("invalid embedding configuration: %w", err)
+	}
+
+	e := &Embedder{dimension: defaultDimension}
+	if opts.Dimension > 0 {
+		e.dimension = opts.Dimension
+	}
+	return e, nil
+}
+
+func (e *Embedder) Dimension() int {
+	return e.dimension
+}
+
+func (e *Embedder) ModelID() string {
+	return "fake"
+}
+
+func (e *Embedder) Embed(
+	ctx context.Context,
+	texts []string,
+	embedType types.EmbeddingInputType,
+) ([][]float32, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	vectors := make([][]float32, len(texts))
+	for i, t := range texts {
+		vectors[i] = e.vector(t)
+	}
+	return vectors, nil
+}
+
+// EmbedAs quantizes the float vectors the way Cohere does: int8 and uint8
+// scale each value to a byte, binary keeps the sign bit of each dimension.
+func (e *Embedder) EmbedAs(
+	ctx context.Context,
+	texts []string,
+	embedType types.EmbeddingInputType,
+	as types.EmbeddingType,
+) (*types.Embeddings, error) {
+	out, err := types.NewEmbeddings(as, len(texts))
+	if err != nil {
+		return nil, err
+	}
+
+	vectors, err := e.Embed(ctx, texts, embedType)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, v := range vectors {
+		switch as {
+		case types.EmbeddingFloat:
+			out.Float[i] = v
+		case types.EmbeddingInt8:
+			out.Int8[i] = make([]int8, len(v))
+			for j, x := range v {
+				out.Int8[i][j] = int8(scale(x, e.dimension))
+			}
+		case types.EmbeddingUint8:
+			out.Uint8[i] = make([]uint8, len(v))
+			for j, x := range v {
+				out.Uint8[i][j] = uint8(scale(x, e.dimension) + 128)
+			}
+		case types.EmbeddingBinary, types.EmbeddingUBinary:
+			packed := pack(v)
+			if as == types.EmbeddingUBinary {
+				out.UBinary[i] = packed
+				continue
+			}
+			out.Binary[i] = make([]int8, len(packed))
+			for j, b := range packed {
+				out.Binary[i][j] = int8(b)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (e *Embedder) vector(text string) []float32 {
+	sum := make([]float64, e.dimension)
+
+	words := tokens(text)
+	if len(words) == 0 {
+		words = []string{text}
+	}
+	for _, w := range words {
+		rng := rand.New(rand.NewPCG(hash(w), 0))
+		for i := range sum {
+			sum[i] += rng.NormFloat64()
+		}
+	}
+
+	var norm float64
+	for _, x := range sum {
+		norm += x * x
+	}
+	norm = math.Sqrt(norm)
+
+	v := make([]float32, e.dimension)
+	for i, x := range sum {
+		v[i] = float32(x / norm)
+	}
+	return v
+}
+
+// scale maps a unit vector component to [-127, 127]. Components of a
+// normalised vector are about 1/sqrt(dimension), so they are stretched by the
+// square root of the dimension and clipped at three standard deviations.
+func scale(x float32, dimension int) int {
+	s := float64(x) * math.Sqrt(float64(dimension)) / 3
+	return max(-127, min(127, int(math.Round(s*127))))
+}
+
+func pack(v []float32) []uint8 {
+	packed := make([]uint8, len(v)/8)
+	for i, x := range v {
+		if x > 0 {
+			packed[i/8] |= 1 << (7 - i%8)
+		}
+	}
+	return packed
+}
+
+func hash(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// tokens splits text into lower-cased words.
+func tokens(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
@@ -0,0 +1,239 @@
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/bexprt/bexgen-client/pkg/ai/types"
+	"github.com/bexprt/bexgen-client/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+type ModelOptions struct {
+	// Fixtures is the path of a YAML or JSON file with scripted responses.
+	Fixtures string `yaml:"fixtures" required:"true"`
+}
+
+// Fixtures is the content of the fixtures file:
+//
+//	responses:
+//	  - match: "(?i)summari[sz]e"
+//	    text: "A short summary."
+//	  - match: "weather"
+//	    toolUses:
+//	      - name: get_weather
+//	        input: {city: Berlin}
+//	default: "I don't know."
+//
+// Responses are tried in order against the text of the last user message.
+type Fixtures struct {
+	Responses []Fixture `yaml:"responses" json:"responses"`
+	Default   *string   `yaml:"default" json:"default"`
+}
+
+type Fixture struct {
+	Match      string           `yaml:"match" json:"match"`
+	Text       string           `yaml:"text" json:"text"`
+	ToolUses   []FixtureToolUse `yaml:"toolUses" json:"toolUses"`
+	StopReason string           `yaml:"stopReason" json:"stopReason"`
+}
+
+type FixtureToolUse struct {
+	Name  string         `yaml:"name" json:"name"`
+	Input map[string]any `yaml:"input" json:"input"`
+}
+
+type response struct {
+	match *regexp.Regexp
+	Fixture
+}
+
+// Model answers prompts with the first matching fixture.
+type Model struct {
+	responses []response
+	fallback  *string
+}
+
+func NewModel(ctx context.Context, cfg *config.FactoryConfig) (types.Model, error) {
+	opts := &ModelOptions{}
+	if err := cfg.DecodeOptions(opts); err != nil {
+		return nil, fmt.Errorf("invalid model configuration: %w", err)
+	}
+
+	b, err := os.ReadFile(opts.Fixtures)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixtures: %w", err)
+	}
+
+	// YAML is a superset of JSON, so both formats decode here.
+	var fixtures Fixtures
+	if err := yaml.Unmarshal(b, &fixtures); err != nil {
+		return nil, fmt.Errorf("failed to parse fixtures %s: %w", opts.Fixtures, err)
+	}
+
+	return NewModelFromFixtures(&fixtures)
+}
+
+// NewModelFromFixtures builds a Model from fixtures defined in code.
+func NewModelFromFixtures(fixtures *Fixtures) (*Model, error) {
+	m := &Model{fallback: fixtures.Default}
+	for i, f := range fixtures.Responses {
+		re, err := regexp.Compile(f.Match)
+		if err != nil {
+			return nil, fmt.Errorf("responses[%d]: invalid match: %w", i, err)
+		}
+		m.responses = append(m.responses, response{match: re, Fixture: f})
+	}
+	return m, nil
+}
+
+func (m *Model) Invoke(ctx context.Context, text string) (string, error) {
+	resp, err := m.Converse(ctx, &types.Request{
+		Messages: []types.Message{types.UserMessage(text)},
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Text(), nil
+}
+
+func (m *Model) Stream(ctx context.Context, text string) (<-chan types.StreamEvent, error) {
+	return m.ConverseStream(ctx, &types.Request{
+		Messages: []types.Message{types.UserMessage(text)},
+	})
+}
+
+func (m *Model) Converse(ctx context.Context, req *types.Request) (*types.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if req == nil || len(req.Messages) == 0 {
+		return nil, fmt.Errorf("request must contain at least one message")
+	}
+
+	prompt := lastUserText(req.Messages)
+	f, ok := m.lookup(prompt)
+	if !ok {
+		return nil, fmt.Errorf("no fixture matches prompt %q", truncate(prompt, 80))
+	}
+
+	msg := types.Message{Role: types.RoleAssistant}
+	if f.Text != "" {
+		msg.Content = append(msg.Content, types.ContentBlock{Text: f.Text})
+	}
+	for i, tu := range f.ToolUses {
+		input, err := json.Marshal(tu.Input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal tool input: %w", err)
+		}
+		if tu.Input == nil {
+			input = json.RawMessage(`{}`)
+		}
+		msg.Content = append(msg.Content, types.ContentBlock{ToolUse: &types.ToolUse{
+			ID:    fmt.Sprintf("fake-%d", i),
+			Name:  tu.Name,
+			Input: input,
+		}})
+	}
+
+	stopReason := f.StopReason
+	if stopReason == "" {
+		stopReason = types.StopEndTurn
+		if len(f.ToolUses) > 0 {
+			stopReason = types.StopToolUse
+		}
+	}
+
+	var inputTokens int
+	for _, msg := range req.Messages {
+		for _, c := range msg.Content {
+			inputTokens += len(strings.Fields(c.Text))
+		}
+	}
+
+	return &types.Response{
+		Message:    msg,
+		StopReason: stopReason,
+		Usage: types.Usage{
+			InputTokens:  inputTokens + len(strings.Fields(req.System)),
+			OutputTokens: len(strings.Fields(f.Text)),
+		},
+	}, nil
+}
+
+// ConverseStream sends the fixture text word by word.
+func (m *Model) ConverseStream(ctx context.Context, req *types.Request) (<-chan types.StreamEvent, error) {
+	resp, err := m.Converse(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan types.StreamEvent)
+	go func() {
+		defer close(events)
+
+		var pending []types.StreamEvent
+		text := resp.Text()
+		for len(text) > 0 {
+			// Keep the separating spaces so the deltas join back to the text.
+			end := strings.IndexByte(text[1:], ' ') + 1
+			if end == 0 {
+				end = len(text)
+			}
+			pending = append(pending, types.StreamEvent{Delta: text[:end]})
+			text = text[end:]
+		}
+		for _, tu := range resp.ToolUses() {
+			pending = append(pending, types.StreamEvent{ToolUse: &tu})
+		}
+		usage := resp.Usage
+		pending = append(pending, types.StreamEvent{StopReason: resp.StopReason, Usage: &usage})
+
+		for _, ev := range pending {
+			select {
+			case events <- ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+func (m *Model) lookup(prompt string) (Fixture, bool) {
+	for _, r := range m.responses {
+		if r.match.MatchString(prompt) {
+			return r.Fixture, true
+		}
+	}
+	if m.fallback != nil {
+		return Fixture{Text: *m.fallback}, true
+	}
+	return Fixture{}, false
+}
+
+func lastUserText(messages []types.Message) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role != types.RoleUser {
+			continue
+		}
+		var b strings.Builder
+		for _, c := range messages[i].Content {
+			b.WriteString(c.Text)
+		}
+		return b.String()
+	}
+	return ""
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
@@ -0,0 +1,64 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/bexprt/bexgen-client/pkg/ai/types"
+	"github.com/bexprt/bexgen-client/pkg/config"
+)
+
+type RerankOptions struct{}
+
+// Reranker scores documents by the share of distinct query words they
+// contain.
+type Reranker struct{}
+
+func NewReranker(ctx context.Context, cfg *config.FactoryConfig) (types.Rerank, error) {
+	if err := cfg.DecodeOptions(&RerankOptions{}); err != nil {
+		return nil, fmt.Errorf("invalid rerank configuration: %w", err)
+	}
+	return &Reranker{}, nil
+}
+
+func (r *Reranker) Rerank(
+	ctx context.Context,
+	query string,
+	documents []string,
+	opts *types.RerankOptions,
+) ([]types.RerankResult, error) {
+	if query == "" {
+		return nil, fmt.Errorf("query cannot be empty")
+	}
+
+	queryWords := map[string]bool{}
+	for _, w := range tokens(query) {
+		queryWords[w] = true
+	}
+
+	results := make([]types.RerankResult, len(documents))
+	for i, doc := range documents {
+		seen := map[string]bool{}
+		for _, w := range tokens(doc) {
+			if queryWords[w] {
+				seen[w] = true
+			}
+		}
+		var score float32
+		if len(queryWords) > 0 {
+			score = float32(len(seen)) / float32(len(queryWords))
+		}
+		results[i] = types.RerankResult{Index: i, Score: score}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+
+	if opts != nil && opts.TopN > 0 && opts.TopN < len(results) {
+		results = results[:opts.TopN]
+	}
+
+	return results, nil
+}
@@ -0,0 +1,346 @@
+package novapro
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"unicode"
+
+	bedrockruntime "github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/bexprt/bexgen-client/pkg/ai/types"
+	"github.com/bexprt/bexgen-client/pkg/ai/usage"
+)
+
+// The types below mirror the Nova InvokeModel request and response bodies.
+
+type novaRequest struct {
+	System          []novaText      `json:"system,omitempty"`
+	Messages        []novaMessage   `json:"messages"`
+	InferenceConfig novaInference   `json:"inferenceConfig"`
+	ToolConfig      *novaToolConfig `json:"toolConfig,omitempty"`
+}
+
+type novaText struct {
+	Text string `json:"text"`
+}
+
+type novaMessage struct {
+	Role    string        `json:"role"`
+	Content []novaContent `json:"content"`
+}
+
+type novaContent struct {
+	Text       string          `json:"text,omitempty"`
+	Image      *novaMedia      `json:"image,omitempty"`
+	Document   *novaMedia      `json:"document,omitempty"`
+	ToolUse    *novaToolUse    `json:"toolUse,omitempty"`
+	ToolResult *novaToolResult `json:"toolResult,omitempty"`
+}
+
+// novaMedia is an image or, with a name, a document block. Bytes are
+// base64 encoded by encoding/json.
+type novaMedia struct {
+	Format string `json:"format"`
+	Name   string `json:"name,omitempty"`
+	Source struct {
+		Bytes []byte `json:"bytes"`
+	} `json:"source"`
+}
+
+type novaToolUse struct {
+	ToolUseID string          `json:"toolUseId"`
+	Name      string          `json:"name"`
+	Input     json.RawMessage `json:"input"`
+}
+
+type novaToolResult struct {
+	ToolUseID string                  `json:"toolUseId"`
+	Content   []novaToolResultContent `json:"content"`
+	Status    string                  `json:"status,omitempty"`
+}
+
+type novaToolResultContent struct {
+	Text string          `json:"text,omitempty"`
+	JSON json.RawMessage `json:"json,omitempty"`
+}
+
+type novaInference struct {
+	MaxTokens     int      `json:"maxTokens,omitempty"`
+	Temperature   *float32 `json:"temperature,omitempty"`
+	TopP          *float32 `json:"topP,omitempty"`
+	StopSequences []string `json:"stopSequences,omitempty"`
+}
+
+type novaToolConfig struct {
+	Tools      []novaTool     `json:"tools"`
+	ToolChoice map[string]any `json:"toolChoice,omitempty"`
+}
+
+type novaTool struct {
+	ToolSpec struct {
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+		InputSchema struct {
+			JSON json.RawMessage `json:"json"`
+		} `json:"inputSchema"`
+	} `json:"toolSpec"`
+}
+
+type novaResponse struct {
+	Output struct {
+		Message novaMessage `json:"message"`
+	} `json:"output"`
+	StopReason string `json:"stopReason"`
+	Usage      struct {
+		InputTokens  int `json:"inputTokens"`
+		OutputTokens int `json:"outputTokens"`
+	} `json:"usage"`
+}
+
+var emptyObject = json.RawMessage(`{}`)
+
+func (s *NovaClient) Converse(ctx context.Context, req *types.Request) (*types.Response, error) {
+	body, err := s.payload(req)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := "application/json"
+	accept := "application/json"
+
+	out, err := s.client.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
+		ModelId:     &s.modelID,
+		ContentType: &contentType,
+		Accept:      &accept,
+		Body:        body,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bedrock converse failed: %w", err)
+	}
+
+	var response novaResponse
+	if err := json.Unmarshal(out.Body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	usage.Report(ctx, usage.Record{
+		ModelID:      s.modelID,
+		Operation:    usage.OperationChat,
+		InputTokens:  response.Usage.InputTokens,
+		OutputTokens: response.Usage.OutputTokens,
+	})
+
+	return &types.Response{
+		Message:    fromNovaMessage(response.Output.Message),
+		StopReason: response.StopReason,
+		Usage: types.Usage{
+			InputTokens:  response.Usage.InputTokens,
+			OutputTokens: response.Usage.OutputTokens,
+		},
+	}, nil
+}
+
+// payload builds the request body, filling unset inference parameters from
+// the configured defaults.
+func (s *NovaClient) payload(req *types.Request) ([]byte, error) {
+	if req == nil || len(req.Messages) == 0 {
+		return nil, fmt.Errorf("request must contain at least one message")
+	}
+
+	nr := novaRequest{
+		InferenceConfig: novaInference{
+			MaxTokens:     req.Inference.MaxTokens,
+			Temperature:   req.Inference.Temperature,
+			TopP:          req.Inference.TopP,
+			StopSequences: req.Inference.StopSequences,
+		},
+	}
+	if nr.InferenceConfig.MaxTokens == 0 {
+		nr.InferenceConfig.MaxTokens = s.MaxTokens
+	}
+	if nr.InferenceConfig.Temperature == nil {
+		temperature := s.Temperature
+		nr.InferenceConfig.Temperature = &temperature
+	}
+
+	if req.System != "" {
+		nr.System = []novaText{{Text: req.System}}
+	}
+
+	for i, m := range req.Messages {
+		if m.Role != types.RoleUser && m.Role != types.RoleAssistant {
+			return nil, fmt.Errorf("messages[%d]: unsupported role %q", i, m.Role)
+		}
+		nm, err := toNovaMessage(m)
+		if err != nil {
+			return nil, fmt.Errorf("messages[%d]: %w", i, err)
+		}
+		nr.Messages = append(nr.Messages, nm)
+	}
+
+	if len(req.Tools) > 0 {
+		tc, err := toNovaToolConfig(req.Tools, req.ToolChoice)
+		if err != nil {
+			return nil, err
+		}
+		nr.ToolConfig = tc
+	}
+
+	body, err := json.Marshal(nr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	return body, nil
+}
+
+func toNovaMessage(m types.Message) (novaMessage, error) {
+	nm := novaMessage{Role: string(m.Role)}
+	for i, c := range m.Content {
+		switch {
+		case c.ToolUse != nil:
+			input := c.ToolUse.Input
+			if len(input) == 0 {
+				input = emptyObject
+			}
+			nm.Content = append(nm.Content, novaContent{ToolUse: &novaToolUse{
+				ToolUseID: c.ToolUse.ID,
+				Name:      c.ToolUse.Name,
+				Input:     input,
+			}})
+		case c.ToolResult != nil:
+			result := &novaToolResult{ToolUseID: c.ToolResult.ToolUseID}
+			if len(c.ToolResult.JSON) > 0 {
+				result.Content = []novaToolResultContent{{JSON: c.ToolResult.JSON}}
+			} else {
+				result.Content = []novaToolResultContent{{Text: c.ToolResult.Text}}
+			}
+			if c.ToolResult.IsError {
+				result.Status = "error"
+			}
+			nm.Content = append(nm.Content, novaContent{ToolResult: result})
+		case c.Image != nil || c.Document != nil:
+			if m.Role != types.RoleUser {
+				return nm, fmt.Errorf("content[%d]: images and documents are only allowed in user messages", i)
+			}
+			media, err := toNovaMedia(c.Image, c.Document)
+			if err != nil {
+				return nm, fmt.Errorf("content[%d]: %w", i, err)
+			}
+			if c.Image != nil {
+				nm.Content = append(nm.Content, novaContent{Image: media})
+			} else {
+				nm.Content = append(nm.Content, novaContent{Document: media})
+			}
+		case c.Text != "":
+			nm.Content = append(nm.Content, novaContent{Text: c.Text})
+		default:
+			return nm, fmt.Errorf("content[%d] is empty", i)
+		}
+	}
+	return nm, nil
+}
+
+func toNovaMedia(image, document *types.Media) (*novaMedia, error) {
+	m := image
+	if m == nil {
+		m = document
+	}
+	if len(m.Data) == 0 {
+		if m.Path != "" {
+			return nil, fmt.Errorf("%s is not loaded, resolve stored media with media.Resolve", m.Path)
+		}
+		return nil, fmt.Errorf("media has no data")
+	}
+
+	nm := &novaMedia{Format: string(m.Format)}
+	nm.Source.Bytes = m.Data
+
+	if image != nil {
+		if !m.Format.IsImage() {
+			return nil, fmt.Errorf("unsupported image format %q", m.Format)
+		}
+		return nm, nil
+	}
+
+	switch m.Format {
+	case types.FormatPDF, types.FormatCSV, types.FormatDOC, types.FormatDOCX,
+		types.FormatXLS, types.FormatXLSX, types.FormatHTML, types.FormatTXT, types.FormatMD:
+	default:
+		return nil, fmt.Errorf("unsupported document format %q", m.Format)
+	}
+	nm.Name = documentName(m.Name)
+	return nm, nil
+}
+
+// documentName keeps the characters Bedrock accepts in document names:
+// letters, digits, single spaces, hyphens, parentheses and brackets.
+func documentName(name string) string {
+	name = strings.TrimSuffix(name, path.Ext(name))
+	var b strings.Builder
+	space := false
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("-()[]", r):
+			b.WriteRune(r)
+			space = false
+		case !space && b.Len() > 0:
+			b.WriteByte(' ')
+			space = true
+		}
+	}
+	if n := strings.TrimSpace(b.String()); n != "" {
+		return n
+	}
+	return "document"
+}
+
+func fromNovaMessage(nm novaMessage) types.Message {
+	m := types.Message{Role: types.Role(nm.Role)}
+	for _, c := range nm.Content {
+		switch {
+		case c.ToolUse != nil:
+			m.Content = append(m.Content, types.ContentBlock{ToolUse: &types.ToolUse{
+				ID:    c.ToolUse.ToolUseID,
+				Name:  c.ToolUse.Name,
+				Input: c.ToolUse.Input,
+			}})
+		case c.Text != "":
+			m.Content = append(m.Content, types.ContentBlock{Text: c.Text})
+		}
+	}
+	return m
+}
+
+func toNovaToolConfig(tools []types.Tool, choice *types.ToolChoice) (*novaToolConfig, error) {
+	tc := &novaToolConfig{}
+	for _, t := range tools {
+		if t.Name == "" {
+			return nil, fmt.Errorf("tool name is required")
+		}
+		var nt novaTool
+		nt.ToolSpec.Name = t.Name
+		nt.ToolSpec.Description = t.Description
+		nt.ToolSpec.InputSchema.JSON = t.InputSchema
+		if len(nt.ToolSpec.InputSchema.JSON) == 0 {
+			nt.ToolSpec.InputSchema.JSON = json.RawMessage(`{"type":"object","properties":{}}`)
+		}
+		tc.Tools = append(tc.Tools, nt)
+	}
+
+	if choice == nil {
+		return tc, nil
+	}
+	switch choice.Type {
+	case types.ToolChoiceAuto, types.ToolChoiceAny:
+		tc.ToolChoice = map[string]any{string(choice.Type): map[string]any{}}
+	case types.ToolChoiceTool:
+		if choice.Name == "" {
+			return nil, fmt.Errorf("tool choice requires a tool name")
+		}
+		tc.ToolChoice = map[string]any{"tool": map[string]any{"name": choice.Name}}
+	default:
+		return nil, fmt.Errorf("unsupported tool choice %q", choice.Type)
+	}
+	return tc, nil
+}
@@ -2,85 +2,85 @@ package novapro
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 
-	awsCfg "github.com/aws/aws-sdk-go-v2/config"
-	bedrockruntime "github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/bexprt/bexgen-client/internal/ai/bedrock"
+	"github.com/bexprt/bexgen-client/internal/awsconfig"
 	"github.com/bexprt/bexgen-client/pkg/ai/types"
+	"github.com/bexprt/bexgen-client/pkg/config"
 )
 
 type NovaClient struct {
-	client      *bedrockruntime.Client
+	client      *bedrock.Client
 	modelID     string
 	MaxTokens   int
 	Temperature float32
 }
 
-func New(ctx context.Context, modelID string, maxTokens int, temperature float32) (types.Model, error) {
-	cfg, err := awsCfg.LoadDefaultConfig(ctx)
+type Options struct {
+	ModelID     string  `yaml:"modelId" required:"true"`
+	MaxTokens   int     `yaml:"maxTokens" required:"true"`
+	Temperature float32 `yaml:"temperature"`
+	// Bedrock configures retries and rate limits.
+	Bedrock bedrock.Options `yaml:"bedrock"`
+	// AWS selects the region, credentials and endpoint of the Bedrock
+	// client.
+	AWS awsconfig.Options `yaml:"aws"`
+}
+
+func (o *Options) Validate() error {
+	var errs []error
+	if o.MaxTokens < 0 {
+		errs = append(errs, fmt.Errorf("maxTokens must be positive, got %d", o.MaxTokens))
+	}
+	if o.Temperature < 0 || o.Temperature > 1 {
+		errs = append(errs, fmt.Errorf("temperature must be between 0 and 1, got %v", o.Temperature))
+	}
+	return errors.Join(errs...)
+}
+
+func New(ctx context.Context, cfg *config.FactoryConfig) (types.Model, error) {
+	opts := &Options{}
+	if err := cfg.DecodeOptions(opts); err != nil {
+		return nil, fmt.Errorf("invalid model configuration: %w", err)
+	}
+
+	acfg, err := awsconfig.Load(ctx, opts.AWS)
 	if err != nil {
 		return nil, err
 	}
 
+	client := bedrock.New(acfg, opts.Bedrock, func(o *bedrockruntime.Options) {
+		o.BaseEndpoint = opts.AWS.BaseEndpoint()
+	})
 	return &NovaClient{
-		client:      bedrockruntime.NewFromConfig(cfg),
-		modelID:     modelID,
-		MaxTokens:   maxTokens,
-		Temperature: temperature,
+		client:      client,
+		modelID:     opts.ModelID,
+		MaxTokens:   opts.MaxTokens,
+		Temperature: opts.Temperature,
 	}, nil
 }
 
 func (s *NovaClient) Invoke(ctx context.Context, text string) (string, error) {
-	payload := map[string]any{
-		"messages": []map[string]any{
-			{
-				"role": "user",
-				"content": []map[string]any{
-					{
-						"text": text,
-					},
-				},
-			},
-		},
-		"inferenceConfig": map[string]any{
-			"maxTokens":   s.MaxTokens,
-			"temperature": s.Temperature,
-		},
-	}
-
-	body, _ := json.Marshal(payload)
-
-	contentType := "application/json"
-	accept := "application/json"
-
-	out, err := s.client.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
-		ModelId:     &s.modelID,
-		ContentType: &contentType,
-		Accept:      &accept,
-		Body:        body,
+	resp, err := s.Converse(ctx, &types.Request{
+		Messages: []types.Message{types.UserMessage(text)},
 	})
 	if err != nil {
 		return "", err
 	}
-	var response struct {
-		Output struct {
-			Message struct {
-				Content []struct {
-					Text string `json:"text"`
-				} `json:"content"`
-				Role string `json:"role"`
-			} `json:"message"`
-		} `json:"output"`
-	}
-
-	if err := json.Unmarshal(out.Body, &response); err != nil {
-		return "", err
-	}
 
-	if len(response.Output.Message.Content) == 0 {
+	out := resp.Text()
+	if out == "" {
 		return "", fmt.Errorf("no summary returned")
 	}
 
-	return response.Output.Message.Content[0].Text, nil
+	return out, nil
+}
+
+func (s *NovaClient) Stream(ctx context.Context, text string) (<-chan types.StreamEvent, error) {
+	return s.ConverseStream(ctx, &types.Request{
+		Messages: []types.Message{types.UserMessage(text)},
+	})
 }
@@ -0,0 +1,197 @@
+package novapro
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	bedrockruntime "github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	bedrocktypes "github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/bexprt/bexgen-client/pkg/ai/types"
+	"github.com/bexprt/bexgen-client/pkg/ai/usage"
+)
+
+// streamChunk is a single Nova response stream chunk. Only one of the fields
+// is set per chunk, except for the invocation metrics Bedrock appends to the
+// last one.
+type streamChunk struct {
+	ContentBlockStart *struct {
+		Start struct {
+			ToolUse *struct {
+				ToolUseID string `json:"toolUseId"`
+				Name      string `json:"name"`
+			} `json:"toolUse"`
+		} `json:"start"`
+		ContentBlockIndex int `json:"contentBlockIndex"`
+	} `json:"contentBlockStart"`
+	ContentBlockDelta *struct {
+		Delta struct {
+			Text    string `json:"text"`
+			ToolUse *struct {
+				Input string `json:"input"`
+			} `json:"toolUse"`
+		} `json:"delta"`
+		ContentBlockIndex int `json:"contentBlockIndex"`
+	} `json:"contentBlockDelta"`
+	ContentBlockStop *struct {
+		ContentBlockIndex int `json:"contentBlockIndex"`
+	} `json:"contentBlockStop"`
+	MessageStop *struct {
+		StopReason string `json:"stopReason"`
+	} `json:"messageStop"`
+	Metadata *struct {
+		Usage struct {
+			InputTokens  int `json:"inputTokens"`
+			OutputTokens int `json:"outputTokens"`
+		} `json:"usage"`
+	} `json:"metadata"`
+	InvocationMetrics *struct {
+		InputTokenCount  int `json:"inputTokenCount"`
+		OutputTokenCount int `json:"outputTokenCount"`
+	} `json:"amazon-bedrock-invocationMetrics"`
+}
+
+// pendingToolUse collects the input of a tool call streamed in pieces.
+type pendingToolUse struct {
+	id, name string
+	input    strings.Builder
+}
+
+func (s *NovaClient) ConverseStream(ctx context.Context, req *types.Request) (<-chan types.StreamEvent, error) {
+	body, err := s.payload(req)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := "application/json"
+	accept := "application/json"
+
+	out, err := s.client.InvokeModelWithResponseStream(ctx, &bedrockruntime.InvokeModelWithResponseStreamInput{
+		ModelId:     &s.modelID,
+		ContentType: &contentType,
+		Accept:      &accept,
+		Body:        body,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bedrock stream failed: %w", err)
+	}
+
+	events := make(chan types.StreamEvent)
+	go s.readStream(ctx, out.GetStream(), events)
+
+	return events, nil
+}
+
+func (s *NovaClient) readStream(
+	ctx context.Context,
+	stream *bedrockruntime.InvokeModelWithResponseStreamEventStream,
+	events chan<- types.StreamEvent,
+) {
+	defer close(events)
+	defer stream.Close()
+
+	send := func(ev types.StreamEvent) bool {
+		select {
+		case events <- ev:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	var (
+		last     types.StreamEvent
+		toolUses = map[int]*pendingToolUse{}
+	)
+	for {
+		var (
+			ev bedrocktypes.ResponseStream
+			ok bool
+		)
+		select {
+		case ev, ok = <-stream.Events():
+		case <-ctx.Done():
+			// The consumer may have gone away, don't block on it.
+			select {
+			case events <- types.StreamEvent{Err: ctx.Err()}:
+			default:
+			}
+			return
+		}
+		if !ok {
+			break
+		}
+
+		part, isChunk := ev.(*bedrocktypes.ResponseStreamMemberChunk)
+		if !isChunk {
+			continue
+		}
+
+		var chunk streamChunk
+		if err := json.Unmarshal(part.Value.Bytes, &chunk); err != nil {
+			send(types.StreamEvent{Err: fmt.Errorf("failed to parse stream chunk: %w", err)})
+			return
+		}
+
+		switch {
+		case chunk.ContentBlockStart != nil:
+			if tu := chunk.ContentBlockStart.Start.ToolUse; tu != nil {
+				toolUses[chunk.ContentBlockStart.ContentBlockIndex] = &pendingToolUse{id: tu.ToolUseID, name: tu.Name}
+			}
+		case chunk.ContentBlockDelta != nil:
+			delta := chunk.ContentBlockDelta.Delta
+			if delta.ToolUse != nil {
+				if p, ok := toolUses[chunk.ContentBlockDelta.ContentBlockIndex]; ok {
+					p.input.WriteString(delta.ToolUse.Input)
+				}
+				continue
+			}
+			if delta.Text == "" {
+				continue
+			}
+			if !send(types.StreamEvent{Delta: delta.Text}) {
+				return
+			}
+		case chunk.ContentBlockStop != nil:
+			p, ok := toolUses[chunk.ContentBlockStop.ContentBlockIndex]
+			if !ok {
+				continue
+			}
+			delete(toolUses, chunk.ContentBlockStop.ContentBlockIndex)
+			input := json.RawMessage(p.input.String())
+			if len(input) == 0 {
+				input = emptyObject
+			}
+			if !send(types.StreamEvent{ToolUse: &types.ToolUse{ID: p.id, Name: p.name, Input: input}}) {
+				return
+			}
+		case chunk.MessageStop != nil:
+			last.StopReason = chunk.MessageStop.StopReason
+		case chunk.Metadata != nil:
+			last.Usage = &types.Usage{
+				InputTokens:  chunk.Metadata.Usage.InputTokens,
+				OutputTokens: chunk.Metadata.Usage.OutputTokens,
+			}
+		}
+		if chunk.InvocationMetrics != nil && last.Usage == nil {
+			last.Usage = &types.Usage{
+				InputTokens:  chunk.InvocationMetrics.InputTokenCount,
+				OutputTokens: chunk.InvocationMetrics.OutputTokenCount,
+			}
+		}
+	}
+
+	if err := stream.Err(); err != nil {
+		last.Err = fmt.Errorf("bedrock stream failed: %w", err)
+	}
+	if last.Usage != nil {
+		usage.Report(ctx, usage.Record{
+			ModelID:      s.modelID,
+			Operation:    usage.OperationChat,
+			InputTokens:  last.Usage.InputTokens,
+			OutputTokens: last.Usage.OutputTokens,
+		})
+	}
+	send(last)
+}
@@ -0,0 +1,312 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/bexprt/bexgen-client/pkg/ai/types"
+	"github.com/bexprt/bexgen-client/pkg/ai/usage"
+	"github.com/bexprt/bexgen-client/pkg/config"
+)
+
+type ModelOptions struct {
+	Client      ClientOptions `yaml:",inline"`
+	MaxTokens   int           `yaml:"maxTokens"`
+	Temperature *float32      `yaml:"temperature"`
+}
+
+func (o *ModelOptions) Validate() error {
+	var errs []error
+	if o.MaxTokens < 0 {
+		errs = append(errs, fmt.Errorf("maxTokens must be positive, got %d", o.MaxTokens))
+	}
+	if o.Temperature != nil && (*o.Temperature < 0 || *o.Temperature > 2) {
+		errs = append(errs, fmt.Errorf("temperature must be between 0 and 2, got %v", *o.Temperature))
+	}
+	return errors.Join(errs...)
+}
+
+type Model struct {
+	client      *client
+	maxTokens   int
+	temperature *float32
+}
+
+// The types below mirror the chat completions request and response bodies.
+
+type chatRequest struct {
+	Model         string         `json:"model"`
+	Messages      []chatMessage  `json:"messages"`
+	MaxTokens     int            `json:"max_tokens,omitempty"`
+	Temperature   *float32       `json:"temperature,omitempty"`
+	TopP          *float32       `json:"top_p,omitempty"`
+	Stop          []string       `json:"stop,omitempty"`
+	Tools         []chatTool     `json:"tools,omitempty"`
+	ToolChoice    any            `json:"tool_choice,omitempty"`
+	Stream        bool           `json:"stream,omitempty"`
+	StreamOptions *streamOptions `json:"stream_options,omitempty"`
+}
+
+type streamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+type chatMessage struct {
+	Role       string     `json:"role"`
+	Content    *string    `json:"content"`
+	ToolCalls  []toolCall `json:"tool_calls,omitempty"`
+	ToolCallID string     `json:"tool_call_id,omitempty"`
+}
+
+type toolCall struct {
+	Index    *int   `json:"index,omitempty"`
+	ID       string `json:"id,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Function struct {
+		Name      string `json:"name,omitempty"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+type chatTool struct {
+	Type     string `json:"type"`
+	Function struct {
+		Name        string          `json:"name"`
+		Description string          `json:"description,omitempty"`
+		Parameters  json.RawMessage `json:"parameters"`
+	} `json:"function"`
+}
+
+type chatUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message      chatMessage `json:"message"`
+		FinishReason string      `json:"finish_reason"`
+	} `json:"choices"`
+	Usage chatUsage `json:"usage"`
+}
+
+func NewModel(ctx context.Context, cfg *config.FactoryConfig) (types.Model, error) {
+	opts := &ModelOptions{}
+	if err := cfg.DecodeOptions(opts); err != nil {
+		return nil, fmt.Errorf("invalid model configuration: %w", err)
+	}
+
+	return &Model{
+		client:      newClient(opts.Client),
+		maxTokens:   opts.MaxTokens,
+		temperature: opts.Temperature,
+	}, nil
+}
+
+func (m *Model) Invoke(ctx context.Context, text string) (string, error) {
+	resp, err := m.Converse(ctx, &types.Request{
+		Messages: []types.Message{types.UserMessage(text)},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	out := resp.Text()
+	if out == "" {
+		return "", fmt.Errorf("no summary returned")
+	}
+
+	return out, nil
+}
+
+func (m *Model) Stream(ctx context.Context, text string) (<-chan types.StreamEvent, error) {
+	return m.ConverseStream(ctx, &types.Request{
+		Messages: []types.Message{types.UserMessage(text)},
+	})
+}
+
+func (m *Model) Converse(ctx context.Context, req *types.Request) (*types.Response, error) {
+	body, err := m.request(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp chatResponse
+	if err := m.client.post(ctx, "/chat/completions", body, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("no choices returned from model")
+	}
+
+	usage.Report(ctx, usage.Record{
+		ModelID:      m.client.model,
+		Operation:    usage.OperationChat,
+		InputTokens:  resp.Usage.PromptTokens,
+		OutputTokens: resp.Usage.CompletionTokens,
+	})
+
+	choice := resp.Choices[0]
+	msg := types.Message{Role: types.RoleAssistant}
+	if choice.Message.Content != nil && *choice.Message.Content != "" {
+		msg.Content = append(msg.Content, types.ContentBlock{Text: *choice.Message.Content})
+	}
+	for _, tc := range choice.Message.ToolCalls {
+		msg.Content = append(msg.Content, types.ContentBlock{ToolUse: toToolUse(tc.ID, tc.Function.Name, tc.Function.Arguments)})
+	}
+
+	return &types.Response{
+		Message:    msg,
+		StopReason: stopReason(choice.FinishReason),
+		Usage: types.Usage{
+			InputTokens:  resp.Usage.PromptTokens,
+			OutputTokens: resp.Usage.CompletionTokens,
+		},
+	}, nil
+}
+
+// request converts a conversation to a chat completions body, filling unset
+// inference parameters from the configured defaults.
+func (m *Model) request(req *types.Request) (*chatRequest, error) {
+	if req == nil || len(req.Messages) == 0 {
+		return nil, fmt.Errorf("request must contain at least one message")
+	}
+
+	cr := &chatRequest{
+		Model:       m.client.model,
+		MaxTokens:   req.Inference.MaxTokens,
+		Temperature: req.Inference.Temperature,
+		TopP:        req.Inference.TopP,
+		Stop:        req.Inference.StopSequences,
+	}
+	if cr.MaxTokens == 0 {
+		cr.MaxTokens = m.maxTokens
+	}
+	if cr.Temperature == nil {
+		cr.Temperature = m.temperature
+	}
+
+	if req.System != "" {
+		cr.Messages = append(cr.Messages, chatMessage{Role: "system", Content: &req.System})
+	}
+
+	for i, msg := range req.Messages {
+		converted, err := toChatMessages(msg)
+		if err != nil {
+			return nil, fmt.Errorf("messages[%d]: %w", i, err)
+		}
+		cr.Messages = append(cr.Messages, converted...)
+	}
+
+	for _, t := range req.Tools {
+		if t.Name == "" {
+			return nil, fmt.Errorf("tool name is required")
+		}
+		var ct chatTool
+		ct.Type = "function"
+		ct.Function.Name = t.Name
+		ct.Function.Description = t.Description
+		ct.Function.Parameters = t.InputSchema
+		if len(ct.Function.Parameters) == 0 {
+			ct.Function.Parameters = json.RawMessage(`{"type":"object","properties":{}}`)
+		}
+		cr.Tools = append(cr.Tools, ct)
+	}
+
+	if req.ToolChoice != nil && len(req.Tools) > 0 {
+		switch req.ToolChoice.Type {
+		case types.ToolChoiceAuto:
+			cr.ToolChoice = "auto"
+		case types.ToolChoiceAny:
+			cr.ToolChoice = "required"
+		case types.ToolChoiceTool:
+			if req.ToolChoice.Name == "" {
+				return nil, fmt.Errorf("tool choice requires a tool name")
+			}
+			cr.ToolChoice = map[string]any{
+				"type":     "function",
+				"function": map[string]any{"name": req.ToolChoice.Name},
+			}
+		default:
+			return nil, fmt.Errorf("unsupported tool choice %q", req.ToolChoice.Type)
+		}
+	}
+
+	return cr, nil
+}
+
+// toChatMessages converts a message. Tool results become separate messages
+// with the tool role.
+func toChatMessages(m types.Message) ([]chatMessage, error) {
+	if m.Role != types.RoleUser && m.Role != types.RoleAssistant {
+		return nil, fmt.Errorf("unsupported role %q", m.Role)
+	}
+
+	var (
+		out  []chatMessage
+		main = chatMessage{Role: string(m.Role)}
+		text string
+	)
+	for i, c := range m.Content {
+		switch {
+		case c.ToolUse != nil:
+			var tc toolCall
+			tc.ID = c.ToolUse.ID
+			tc.Type = "function"
+			tc.Function.Name = c.ToolUse.Name
+			tc.Function.Arguments = string(c.ToolUse.Input)
+			if tc.Function.Arguments == "" {
+				tc.Function.Arguments = "{}"
+			}
+			main.ToolCalls = append(main.ToolCalls, tc)
+		case c.ToolResult != nil:
+			content := c.ToolResult.Text
+			if len(c.ToolResult.JSON) > 0 {
+				content = string(c.ToolResult.JSON)
+			}
+			if c.ToolResult.IsError {
+				content = "error: " + content
+			}
+			out = append(out, chatMessage{Role: "tool", Content: &content, ToolCallID: c.ToolResult.ToolUseID})
+		case c.Image != nil || c.Document != nil:
+			return nil, fmt.Errorf("content[%d]: images and documents are not supported by this driver", i)
+		case c.Text != "":
+			text += c.Text
+		default:
+			return nil, fmt.Errorf("content[%d] is empty", i)
+		}
+	}
+
+	if text != "" || len(main.ToolCalls) > 0 {
+		if text != "" {
+			main.Content = &text
+		}
+		out = append(out, main)
+	}
+	return out, nil
+}
+
+func toToolUse(id, name, arguments string) *types.ToolUse {
+	input := json.RawMessage(arguments)
+	if len(input) == 0 {
+		input = json.RawMessage(`{}`)
+	}
+	return &types.ToolUse{ID: id, Name: name, Input: input}
+}
+
+// stopReason maps OpenAI finish reasons to the shared stop reasons.
+func stopReason(finish string) string {
+	switch finish {
+	case "stop":
+		return types.StopEndTurn
+	case "length":
+		return types.StopMaxTokens
+	case "tool_calls", "function_call":
+		return types.StopToolUse
+	case "content_filter":
+		return types.StopContentFiltered
+	}
+	return finish
+}
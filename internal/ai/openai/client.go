@@ -0,0 +1,126 @@
+// Package openai implements the model and embedding drivers for servers
+// exposing the OpenAI HTTP API, such as llama.cpp, Ollama or vLLM.
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const defaultTimeout = 60 * time.Second
+
+// ClientOptions are shared by the model and embedding drivers.
+type ClientOptions struct {
+	BaseURL string        `yaml:"baseUrl" required:"true"`
+	APIKey  string        `yaml:"apiKey"`
+	Model   string        `yaml:"model" required:"true"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+type client struct {
+	http    *http.Client
+	baseURL string
+	apiKey  string
+	model   string
+}
+
+func newClient(opts ClientOptions) *client {
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &client{
+		http:    &http.Client{Timeout: timeout},
+		baseURL: strings.TrimRight(opts.BaseURL, "/"),
+		apiKey:  opts.APIKey,
+		model:   opts.Model,
+	}
+}
+
+// streamClient shares the transport but has no overall timeout, since
+// streams last as long as the generation.
+func (c *client) streamClient() *http.Client {
+	return &http.Client{Transport: c.http.Transport}
+}
+
+func (c *client) newRequest(ctx context.Context, path string, body any) (*http.Request, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	return req, nil
+}
+
+// post sends body as JSON and decodes the response into out.
+func (c *client) post(ctx context.Context, path string, body, out any) error {
+	req, err := c.newRequest(ctx, path, body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("openai request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	var apiErr struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	msg := strings.TrimSpace(string(b))
+	if json.Unmarshal(b, &apiErr) == nil && apiErr.Error.Message != "" {
+		msg = apiErr.Error.Message
+	}
+
+	return &APIError{StatusCode: resp.StatusCode, Status: resp.Status, Message: msg}
+}
+
+// APIError is a non-2xx response of the server.
+type APIError struct {
+	StatusCode int
+	Status     string
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("openai request failed: %s: %s", e.Status, e.Message)
+}
+
+// HTTPStatusCode lets bedrock.Classify sort the error.
+func (e *APIError) HTTPStatusCode() int {
+	return e.StatusCode
+}
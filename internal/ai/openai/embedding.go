@@ -0,0 +1,177 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bexprt/bexgen-client/pkg/ai/types"
+	"github.com/bexprt/bexgen-client/pkg/ai/usage"
+	"github.com/bexprt/bexgen-client/pkg/config"
+)
+
+const defaultBatchSize = 64
+
+type EmbedderOptions struct {
+	Client    ClientOptions `yaml:",inline"`
+	Dimension int           `yaml:"dimension" required:"true"`
+	// SendDimensions passes the dimension to the server, for models that
+	// support shortening their embeddings.
+	SendDimensions bool `yaml:"sendDimensions"`
+	BatchSize      int  `yaml:"batchSize"`
+}
+
+func (o *EmbedderOptions) Validate() error {
+	if o.Dimension < 0 {
+		return fmt.Errorf("dimension must be positive, got %d", o.Dimension)
+	}
+	if o.BatchSize < 0 {
+		return fmt.Errorf("batchSize must be positive, got %d", o.BatchSize)
+	}
+	return nil
+}
+
+type Embedder struct {
+	client         *client
+	dimension      int
+	sendDimensions bool
+	batchSize      int
+}
+
+type embeddingRequest struct {
+	Model          string   `json:"model"`
+	Input          []string `json:"input"`
+	EncodingFormat string   `json:"encoding_format"`
+	Dimensions     int      `json:"dimensions,omitempty"`
+}
+
+type embeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Usage struct {
+		PromptTokens int `json:"prompt_tokens"`
+	} `json:"usage"`
+}
+
+func NewEmbedder(ctx context.Context, cfg *config.FactoryConfig) (types.Embedder, error) {
+	opts := &EmbedderOptions{}
+	if err := cfg.DecodeOptions(opts); err != nil {
+		return nil, fmt.Errorf("invalid embedding configuration: %w", err)
+	}
+
+	e := &Embedder{
+		client:         newClient(opts.Client),
+		dimension:      opts.Dimension,
+		sendDimensions: opts.SendDimensions,
+		batchSize:      defaultBatchSize,
+	}
+	if opts.BatchSize > 0 {
+		e.batchSize = opts.BatchSize
+	}
+
+	return e, nil
+}
+
+func (e *Embedder) Dimension() int {
+	return e.dimension
+}
+
+func (e *Embedder) ModelID() string {
+	return e.client.model
+}
+
+// Embed sends texts in sequential batches. The input type is ignored, the
+// OpenAI API has no equivalent.
+func (e *Embedder) Embed(
+	ctx context.Context,
+	texts []string,
+	embedType types.EmbeddingInputType,
+) ([][]float32, error) {
+	var (
+		vectors  = make([][]float32, len(texts))
+		failures []types.BatchFailure
+		failed   int
+	)
+
+	for start := 0; start < len(texts); start += e.batchSize {
+		end := min(start+e.batchSize, len(texts))
+
+		result, err := e.embedBatch(ctx, texts[start:end])
+		if err != nil {
+			f := types.BatchFailure{Err: err}
+			for i := start; i < end; i++ {
+				f.Indices = append(f.Indices, i)
+			}
+			failures = append(failures, f)
+			failed += len(f.Indices)
+			continue
+		}
+		copy(vectors[start:end], result)
+	}
+
+	switch {
+	case len(failures) == 0:
+		return vectors, nil
+	case failed == len(texts):
+		return nil, failures[0].Err
+	default:
+		return vectors, &types.BatchError{Total: len(texts), Failures: failures}
+	}
+}
+
+// EmbedAs only supports float embeddings.
+func (e *Embedder) EmbedAs(
+	ctx context.Context,
+	texts []string,
+	embedType types.EmbeddingInputType,
+	as types.EmbeddingType,
+) (*types.Embeddings, error) {
+	if as != types.EmbeddingFloat {
+		return nil, fmt.Errorf("unsupported embedding type %q", as)
+	}
+
+	vectors, err := e.Embed(ctx, texts, embedType)
+	if vectors == nil {
+		return nil, err
+	}
+	return &types.Embeddings{Type: types.EmbeddingFloat, Float: vectors}, err
+}
+
+func (e *Embedder) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	req := &embeddingRequest{
+		Model:          e.client.model,
+		Input:          texts,
+		EncodingFormat: "float",
+	}
+	if e.sendDimensions {
+		req.Dimensions = e.dimension
+	}
+
+	var resp embeddingResponse
+	if err := e.client.post(ctx, "/embeddings", req, &resp); err != nil {
+		return nil, err
+	}
+	usage.Report(ctx, usage.Record{
+		ModelID:     e.client.model,
+		Operation:   usage.OperationEmbed,
+		InputTokens: resp.Usage.PromptTokens,
+	})
+
+	if len(resp.Data) != len(texts) {
+		return nil, fmt.Errorf("server returned %d embeddings for %d texts", len(resp.Data), len(texts))
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, d := range resp.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("server returned out of range index %d", d.Index)
+		}
+		if len(d.Embedding) != e.dimension {
+			return nil, fmt.Errorf("server returned %d dimensions, expected %d", len(d.Embedding), e.dimension)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+
+	return vectors, nil
+}
@@ -0,0 +1,168 @@
+package openai
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/bexprt/bexgen-client/pkg/ai/types"
+	"github.com/bexprt/bexgen-client/pkg/ai/usage"
+)
+
+type chatChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content   string     `json:"content"`
+			ToolCalls []toolCall `json:"tool_calls"`
+		} `json:"delta"`
+		FinishReason *string `json:"finish_reason"`
+	} `json:"choices"`
+	Usage *chatUsage `json:"usage"`
+}
+
+// pendingToolCall collects a tool call streamed in pieces.
+type pendingToolCall struct {
+	id, name  string
+	arguments strings.Builder
+}
+
+func (m *Model) ConverseStream(ctx context.Context, req *types.Request) (<-chan types.StreamEvent, error) {
+	body, err := m.request(req)
+	if err != nil {
+		return nil, err
+	}
+	body.Stream = true
+	body.StreamOptions = &streamOptions{IncludeUsage: true}
+
+	httpReq, err := m.client.newRequest(ctx, "/chat/completions", body)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Accept", "text/event-stream")
+
+	resp, err := m.client.streamClient().Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("openai request failed: %w", err)
+	}
+	if err := checkResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	events := make(chan types.StreamEvent)
+	go readStream(ctx, m.client.model, resp.Body, events)
+
+	return events, nil
+}
+
+// readStream parses server-sent events until the [DONE] marker. Cancelling
+// ctx aborts the request, which unblocks the body reads.
+func readStream(ctx context.Context, modelID string, body io.ReadCloser, events chan<- types.StreamEvent) {
+	defer close(events)
+	defer body.Close()
+
+	send := func(ev types.StreamEvent) bool {
+		select {
+		case events <- ev:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	var (
+		last  types.StreamEvent
+		calls = map[int]*pendingToolCall{}
+	)
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			break
+		}
+
+		var chunk chatChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			send(types.StreamEvent{Err: fmt.Errorf("failed to parse stream chunk: %w", err)})
+			return
+		}
+
+		if chunk.Usage != nil {
+			last.Usage = &types.Usage{
+				InputTokens:  chunk.Usage.PromptTokens,
+				OutputTokens: chunk.Usage.CompletionTokens,
+			}
+		}
+		if len(chunk.Choices) == 0 {
+			continue
+		}
+
+		choice := chunk.Choices[0]
+		for i, tc := range choice.Delta.ToolCalls {
+			index := i
+			if tc.Index != nil {
+				index = *tc.Index
+			}
+			p, ok := calls[index]
+			if !ok {
+				p = &pendingToolCall{}
+				calls[index] = p
+			}
+			if tc.ID != "" {
+				p.id = tc.ID
+			}
+			if tc.Function.Name != "" {
+				p.name = tc.Function.Name
+			}
+			p.arguments.WriteString(tc.Function.Arguments)
+		}
+		if choice.Delta.Content != "" {
+			if !send(types.StreamEvent{Delta: choice.Delta.Content}) {
+				return
+			}
+		}
+		if choice.FinishReason != nil && *choice.FinishReason != "" {
+			last.StopReason = stopReason(*choice.FinishReason)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		send(types.StreamEvent{Err: fmt.Errorf("openai stream failed: %w", err)})
+		return
+	}
+
+	indices := make([]int, 0, len(calls))
+	for i := range calls {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+	for _, i := range indices {
+		p := calls[i]
+		if !send(types.StreamEvent{ToolUse: toToolUse(p.id, p.name, p.arguments.String())}) {
+			return
+		}
+	}
+
+	if last.Usage != nil {
+		usage.Report(ctx, usage.Record{
+			ModelID:      modelID,
+			Operation:    usage.OperationChat,
+			InputTokens:  last.Usage.InputTokens,
+			OutputTokens: last.Usage.OutputTokens,
+		})
+	}
+	send(last)
+}
@@ -0,0 +1,90 @@
+package router
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBreakerOpensAfterThreshold(t *testing.T) {
+	now := time.Now()
+	b := newBreaker(3, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if !b.allow(now) {
+			t.Fatalf("call %d rejected before the threshold", i)
+		}
+		b.failure(now)
+	}
+	if !b.allow(now) {
+		t.Fatal("call rejected before the threshold")
+	}
+	b.failure(now)
+
+	if b.allow(now) {
+		t.Fatal("call allowed with an open circuit")
+	}
+	if b.allow(now.Add(59 * time.Second)) {
+		t.Fatal("call allowed before openDuration passed")
+	}
+}
+
+func TestBreakerSuccessResetsFailures(t *testing.T) {
+	now := time.Now()
+	b := newBreaker(2, time.Minute)
+
+	b.failure(now)
+	b.success()
+	b.failure(now)
+	if !b.allow(now) {
+		t.Fatal("failures before a success counted towards the threshold")
+	}
+}
+
+func TestBreakerSingleProbe(t *testing.T) {
+	now := time.Now()
+	b := newBreaker(1, time.Minute)
+	b.failure(now)
+
+	later := now.Add(time.Minute)
+	if !b.allow(later) {
+		t.Fatal("probe rejected after openDuration")
+	}
+	if b.allow(later) {
+		t.Fatal("second call allowed while probing")
+	}
+
+	// A failed probe reopens the circuit for another openDuration.
+	b.failure(later)
+	if b.allow(later.Add(59 * time.Second)) {
+		t.Fatal("call allowed after a failed probe")
+	}
+
+	evenLater := later.Add(time.Minute)
+	if !b.allow(evenLater) {
+		t.Fatal("probe rejected after the second openDuration")
+	}
+	b.success()
+	if !b.allow(evenLater) || !b.allow(evenLater) {
+		t.Fatal("calls rejected after a successful probe")
+	}
+}
+
+func TestBreakerReleaseEndsProbe(t *testing.T) {
+	now := time.Now()
+	b := newBreaker(1, time.Minute)
+	b.failure(now)
+
+	later := now.Add(time.Minute)
+	if !b.allow(later) {
+		t.Fatal("probe rejected after openDuration")
+	}
+	b.release()
+
+	// The circuit stays open, but the next call may probe again.
+	if !b.allow(later) {
+		t.Fatal("probe rejected after release")
+	}
+	if b.allow(later) {
+		t.Fatal("second call allowed while probing")
+	}
+}
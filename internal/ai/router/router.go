@@ -0,0 +1,225 @@
+// Package router implements a model driver that spreads calls over an
+// ordered list of backend models: a backend is skipped when the prompt is
+// larger than it accepts or its circuit breaker is open, and the next one is
+// tried when a call fails with a retryable error.
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bexprt/bexgen-client/internal/ai/bedrock"
+	"github.com/bexprt/bexgen-client/pkg/ai/chunker"
+	"github.com/bexprt/bexgen-client/pkg/ai/types"
+	"github.com/bexprt/bexgen-client/pkg/config"
+)
+
+const (
+	defaultFailureThreshold = 3
+	defaultOpenDuration     = 30 * time.Second
+)
+
+type Options struct {
+	// Backends are tried in order.
+	Backends []Backend `yaml:"backends" required:"true"`
+	// FailureThreshold is the number of consecutive retryable failures that
+	// opens the circuit of a backend. Defaults to 3.
+	FailureThreshold int `yaml:"failureThreshold"`
+	// OpenDuration is how long an open circuit skips its backend before a
+	// single probe call is let through. Defaults to 30s.
+	OpenDuration time.Duration `yaml:"openDuration"`
+}
+
+type Backend struct {
+	// Name identifies the backend in errors. Defaults to the driver name
+	// and position.
+	Name string `yaml:"name"`
+	// MaxPromptTokens skips the backend for larger prompts, so that a
+	// cheaper model listed first only serves small ones. 0 means no limit.
+	MaxPromptTokens int                  `yaml:"maxPromptTokens"`
+	Model           config.FactoryConfig `yaml:"model" required:"true"`
+}
+
+func (o *Options) Validate() error {
+	var errs []error
+	for i := range o.Backends {
+		b := &o.Backends[i]
+		if b.Model.Driver == "" {
+			errs = append(errs, fmt.Errorf("backends[%d].model.driver is required", i))
+		} else if err := config.ValidateOptions(config.SectionModel, &b.Model); err != nil {
+			errs = append(errs, fmt.Errorf("backends[%d].model.options: %w", i, err))
+		}
+		if b.MaxPromptTokens < 0 {
+			errs = append(errs, fmt.Errorf("backends[%d].maxPromptTokens must be positive, got %d", i, b.MaxPromptTokens))
+		}
+	}
+	if o.FailureThreshold < 0 {
+		errs = append(errs, fmt.Errorf("failureThreshold must be positive, got %d", o.FailureThreshold))
+	}
+	if o.OpenDuration < 0 {
+		errs = append(errs, fmt.Errorf("openDuration must be positive, got %s", o.OpenDuration))
+	}
+	return errors.Join(errs...)
+}
+
+// Builder builds a backend model from its config, usually through the model
+// driver registry.
+type Builder func(ctx context.Context, cfg *config.FactoryConfig) (types.Model, error)
+
+type backend struct {
+	name            string
+	maxPromptTokens int
+	model           types.Model
+	breaker         *breaker
+}
+
+type Router struct {
+	backends  []*backend
+	tokenizer chunker.Tokenizer
+}
+
+// NewConstructor returns the constructor of the router driver. build
+// creates the backend models, which lets the driver registry register a
+// driver that depends on itself.
+func NewConstructor(build Builder) func(ctx context.Context, cfg *config.FactoryConfig) (types.Model, error) {
+	return func(ctx context.Context, cfg *config.FactoryConfig) (types.Model, error) {
+		opts := &Options{}
+		if err := cfg.DecodeOptions(opts); err != nil {
+			return nil, fmt.Errorf("invalid model configuration: %w", err)
+		}
+		return New(ctx, opts, build)
+	}
+}
+
+func New(ctx context.Context, opts *Options, build Builder) (*Router, error) {
+	threshold := opts.FailureThreshold
+	if threshold == 0 {
+		threshold = defaultFailureThreshold
+	}
+	openDuration := opts.OpenDuration
+	if openDuration == 0 {
+		openDuration = defaultOpenDuration
+	}
+
+	r := &Router{tokenizer: chunker.HeuristicTokenizer{}}
+	for i := range opts.Backends {
+		b := &opts.Backends[i]
+		name := b.Name
+		if name == "" {
+			name = fmt.Sprintf("%s#%d", b.Model.Driver, i)
+		}
+
+		model, err := build(ctx, &b.Model)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build backend %s: %w", name, err)
+		}
+		r.backends = append(r.backends, &backend{
+			name:            name,
+			maxPromptTokens: b.MaxPromptTokens,
+			model:           model,
+			breaker:         newBreaker(threshold, openDuration),
+		})
+	}
+	if len(r.backends) == 0 {
+		return nil, fmt.Errorf("router requires at least one backend")
+	}
+
+	return r, nil
+}
+
+func (r *Router) Invoke(ctx context.Context, prompt string) (string, error) {
+	return route(ctx, r, r.tokenizer.Count(prompt), func(m types.Model) (string, error) {
+		return m.Invoke(ctx, prompt)
+	})
+}
+
+// Stream fails over only while opening the stream; errors reported by the
+// stream itself are passed through.
+func (r *Router) Stream(ctx context.Context, prompt string) (<-chan types.StreamEvent, error) {
+	return route(ctx, r, r.tokenizer.Count(prompt), func(m types.Model) (<-chan types.StreamEvent, error) {
+		return m.Stream(ctx, prompt)
+	})
+}
+
+func (r *Router) Converse(ctx context.Context, req *types.Request) (*types.Response, error) {
+	return route(ctx, r, r.promptTokens(req), func(m types.Model) (*types.Response, error) {
+		return m.Converse(ctx, req)
+	})
+}
+
+// ConverseStream fails over only while opening the stream, like Stream.
+func (r *Router) ConverseStream(ctx context.Context, req *types.Request) (<-chan types.StreamEvent, error) {
+	return route(ctx, r, r.promptTokens(req), func(m types.Model) (<-chan types.StreamEvent, error) {
+		return m.ConverseStream(ctx, req)
+	})
+}
+
+func route[T any](ctx context.Context, r *Router, tokens int, call func(types.Model) (T, error)) (T, error) {
+	var (
+		zero T
+		errs []error
+	)
+	for _, b := range r.backends {
+		if b.maxPromptTokens > 0 && tokens > b.maxPromptTokens {
+			continue
+		}
+		if !b.breaker.allow(time.Now()) {
+			errs = append(errs, fmt.Errorf("%s: circuit open", b.name))
+			continue
+		}
+
+		out, err := call(b.model)
+		if err == nil {
+			b.breaker.success()
+			return out, nil
+		}
+		if ctx.Err() != nil {
+			b.breaker.release()
+			return zero, err
+		}
+		// Permanent errors, such as an invalid request, would fail on any
+		// backend and say nothing about this one's health.
+		if bedrock.Classify(err) == bedrock.Permanent {
+			b.breaker.release()
+			return zero, err
+		}
+
+		b.breaker.failure(time.Now())
+		errs = append(errs, fmt.Errorf("%s: %w", b.name, err))
+	}
+
+	if len(errs) == 0 {
+		return zero, fmt.Errorf("no model backend accepts a prompt of about %d tokens", tokens)
+	}
+	return zero, fmt.Errorf("all model backends failed: %w", errors.Join(errs...))
+}
+
+// promptTokens estimates the size of a request, tools included.
+func (r *Router) promptTokens(req *types.Request) int {
+	if req == nil {
+		return 0
+	}
+
+	var b strings.Builder
+	b.WriteString(req.System)
+	for _, m := range req.Messages {
+		for _, c := range m.Content {
+			b.WriteString(c.Text)
+			if c.ToolUse != nil {
+				b.Write(c.ToolUse.Input)
+			}
+			if c.ToolResult != nil {
+				b.WriteString(c.ToolResult.Text)
+				b.Write(c.ToolResult.JSON)
+			}
+		}
+	}
+	for _, t := range req.Tools {
+		b.WriteString(t.Description)
+		b.Write(t.InputSchema)
+	}
+	return r.tokenizer.Count(b.String())
+}
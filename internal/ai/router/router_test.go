@@ -0,0 +1,166 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	brtypes "github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+
+	"github.com/bexprt/bexgen-client/pkg/ai/types"
+	"github.com/bexprt/bexgen-client/pkg/config"
+)
+
+// stubModel answers Invoke with the next error of errs, or "ok" once they
+// run out.
+type stubModel struct {
+	errs  []error
+	calls int
+}
+
+func (m *stubModel) Invoke(ctx context.Context, prompt string) (string, error) {
+	m.calls++
+	if len(m.errs) == 0 {
+		return "ok", nil
+	}
+	err := m.errs[0]
+	m.errs = m.errs[1:]
+	return "", err
+}
+
+func (m *stubModel) Stream(ctx context.Context, prompt string) (<-chan types.StreamEvent, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *stubModel) Converse(ctx context.Context, req *types.Request) (*types.Response, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *stubModel) ConverseStream(ctx context.Context, req *types.Request) (<-chan types.StreamEvent, error) {
+	return nil, errors.New("not implemented")
+}
+
+func newTestRouter(t *testing.T, models ...*stubModel) *Router {
+	t.Helper()
+	opts := &Options{FailureThreshold: 1, OpenDuration: time.Hour}
+	for range models {
+		opts.Backends = append(opts.Backends, Backend{Model: config.FactoryConfig{Driver: "stub"}})
+	}
+
+	var i int
+	r, err := New(context.Background(), opts, func(ctx context.Context, cfg *config.FactoryConfig) (types.Model, error) {
+		m := models[i]
+		i++
+		return m, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func retryable() error {
+	return &brtypes.ServiceUnavailableException{}
+}
+
+func TestRouteFailsOver(t *testing.T) {
+	first := &stubModel{errs: []error{retryable()}}
+	second := &stubModel{}
+	r := newTestRouter(t, first, second)
+
+	out, err := r.Invoke(context.Background(), "hi")
+	if err != nil || out != "ok" {
+		t.Fatalf("Invoke = %q, %v", out, err)
+	}
+
+	// The first backend's circuit is open, so it is skipped.
+	if _, err := r.Invoke(context.Background(), "hi"); err != nil {
+		t.Fatal(err)
+	}
+	if first.calls != 1 || second.calls != 2 {
+		t.Fatalf("calls = %d, %d, want 1, 2", first.calls, second.calls)
+	}
+}
+
+func TestRoutePermanentErrorKeepsCircuitClosed(t *testing.T) {
+	first := &stubModel{errs: []error{errors.New("invalid request")}}
+	second := &stubModel{}
+	r := newTestRouter(t, first, second)
+
+	if _, err := r.Invoke(context.Background(), "hi"); err == nil {
+		t.Fatal("expected the permanent error")
+	}
+	if second.calls != 0 {
+		t.Fatal("a permanent error failed over")
+	}
+	if !r.backends[0].breaker.allow(time.Now()) {
+		t.Fatal("a permanent error opened the circuit")
+	}
+}
+
+func TestRouteReleasesProbe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+	}{
+		{"permanent", context.Background(), errors.New("invalid request")},
+		{"cancelled", ctx, retryable()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &stubModel{errs: []error{tt.err}}
+			r := newTestRouter(t, m)
+			b := r.backends[0].breaker
+			// Open the circuit and let it reach the half-open state.
+			b.failure(time.Now().Add(-2 * time.Hour))
+
+			if _, err := r.Invoke(tt.ctx, "hi"); err == nil {
+				t.Fatal("expected an error")
+			}
+			if !b.allow(time.Now()) {
+				t.Fatal("the probe was not released")
+			}
+		})
+	}
+}
+
+type stubOptions struct {
+	ModelID string `yaml:"modelId" required:"true"`
+}
+
+func TestLoadConfigValidatesBackendOptions(t *testing.T) {
+	config.RegisterOptions(config.SectionModel, "router", func() any { return &Options{} })
+	config.RegisterOptions(config.SectionModel, "stub", func() any { return &stubOptions{} })
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := `storage:
+  driver: test
+model:
+  driver: router
+  options:
+    backends:
+      - model:
+          driver: stub
+          options:
+            modelID: typo
+`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := config.LoadConfig(path)
+	if err == nil {
+		t.Fatal("expected invalid backend options to fail LoadConfig")
+	}
+	if !strings.Contains(err.Error(), "backends[0].model.options") {
+		t.Fatalf("error does not point at the backend: %v", err)
+	}
+}
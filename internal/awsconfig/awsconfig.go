@@ -0,0 +1,107 @@
+// Package awsconfig builds the AWS config shared by the Bedrock, S3 and
+// OpenSearch drivers from their YAML options, on top of the SDK default
+// chain (environment, shared config files, instance and pod roles).
+package awsconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// Options override the default chain. Unset fields keep the SDK defaults.
+type Options struct {
+	Region string `yaml:"region"`
+	// Profile selects a profile of the shared config and credentials files.
+	Profile string `yaml:"profile"`
+	// Static credentials, mostly for local stand-ins.
+	AccessKeyID     string `yaml:"accessKeyId"`
+	SecretAccessKey string `yaml:"secretAccessKey"`
+	SessionToken    string `yaml:"sessionToken"`
+	// RoleARN is assumed through STS with the credentials above, e.g. to
+	// call a service in another account.
+	RoleARN         string        `yaml:"roleArn"`
+	ExternalID      string        `yaml:"externalId"`
+	RoleSessionName string        `yaml:"roleSessionName"`
+	RoleDuration    time.Duration `yaml:"roleDuration"`
+	// Endpoint replaces the endpoint of the driver's service client, e.g. for
+	// LocalStack or MinIO. STS keeps its default endpoint to assume RoleARN.
+	Endpoint string `yaml:"endpoint"`
+}
+
+func (o *Options) Validate() error {
+	var errs []error
+	if (o.AccessKeyID == "") != (o.SecretAccessKey == "") {
+		errs = append(errs, fmt.Errorf("accessKeyId and secretAccessKey must be set together"))
+	}
+	if o.SessionToken != "" && o.AccessKeyID == "" {
+		errs = append(errs, fmt.Errorf("sessionToken requires accessKeyId and secretAccessKey"))
+	}
+	if o.Profile != "" && o.AccessKeyID != "" {
+		errs = append(errs, fmt.Errorf("profile and accessKeyId are mutually exclusive"))
+	}
+	if o.RoleARN == "" && (o.ExternalID != "" || o.RoleSessionName != "" || o.RoleDuration != 0) {
+		errs = append(errs, fmt.Errorf("externalId, roleSessionName and roleDuration require roleArn"))
+	}
+	if o.RoleDuration < 0 {
+		errs = append(errs, fmt.Errorf("roleDuration must be positive, got %s", o.RoleDuration))
+	}
+	if o.Endpoint != "" {
+		if u, err := url.Parse(o.Endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("endpoint must be an absolute URL, got %q", o.Endpoint))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// BaseEndpoint returns Endpoint for the BaseEndpoint option of a service
+// client, or nil to keep the default.
+func (o *Options) BaseEndpoint() *string {
+	if o.Endpoint == "" {
+		return nil
+	}
+	return aws.String(o.Endpoint)
+}
+
+// Load resolves the AWS config. The assumed role credentials are cached and
+// refreshed before they expire.
+func Load(ctx context.Context, opts Options) (aws.Config, error) {
+	var loadOpts []func(*config.LoadOptions) error
+	if opts.Region != "" {
+		loadOpts = append(loadOpts, config.WithRegion(opts.Region))
+	}
+	if opts.Profile != "" {
+		loadOpts = append(loadOpts, config.WithSharedConfigProfile(opts.Profile))
+	}
+	if opts.AccessKeyID != "" {
+		loadOpts = append(loadOpts, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(opts.AccessKeyID, opts.SecretAccessKey, opts.SessionToken),
+		))
+	}
+
+	acfg, err := config.LoadDefaultConfig(ctx, loadOpts...)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	if opts.RoleARN != "" {
+		provider := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(acfg), opts.RoleARN, func(o *stscreds.AssumeRoleOptions) {
+			if opts.ExternalID != "" {
+				o.ExternalID = aws.String(opts.ExternalID)
+			}
+			o.RoleSessionName = opts.RoleSessionName
+			o.Duration = opts.RoleDuration
+		})
+		acfg.Credentials = aws.NewCredentialsCache(provider)
+	}
+
+	return acfg, nil
+}
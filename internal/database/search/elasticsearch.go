@@ -12,6 +12,7 @@ import (
 	"github.com/elastic/go-elasticsearch/v9/typedapi/core/search"
 	"github.com/elastic/go-elasticsearch/v9/typedapi/esdsl"
 	"github.com/elastic/go-elasticsearch/v9/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v9/typedapi/types/enums/densevectorelementtype"
 	"github.com/elastic/go-elasticsearch/v9/typedapi/types/enums/densevectorsimilarity"
 )
 
@@ -21,27 +22,16 @@ type ElasticSearchClient struct {
 }
 
 type ElasticSearchConfig struct {
-	Endpoint string `yaml:"endpoint" mapstructure:"endpoint"`
+	Endpoint string `yaml:"endpoint" mapstructure:"endpoint" required:"true"`
 	Username string `yaml:"username" mapstructure:"username"`
 	Password string `yaml:"password" mapstructure:"password"`
-	Index    string `yaml:"index" mapstructure:"index"`
+	Index    string `yaml:"index" mapstructure:"index" required:"true"`
 }
 
 func NewClientElasticSearch(ctx context.Context, cfg *cfg.FactoryConfig) (searchtypes.Index, error) {
 	osCfg := &ElasticSearchConfig{}
-	if len(cfg.Options) > 0 {
-		if endpoint, ok := cfg.Options["endpoint"].(string); ok {
-			osCfg.Endpoint = endpoint
-		}
-		if username, ok := cfg.Options["username"].(string); ok {
-			osCfg.Username = username
-		}
-		if password, ok := cfg.Options["password"].(string); ok {
-			osCfg.Password = password
-		}
-		if index, ok := cfg.Options["index"].(string); ok {
-			osCfg.Index = index
-		}
+	if err := cfg.DecodeOptions(osCfg); err != nil {
+		return nil, fmt.Errorf("invalid search configuration: %w", err)
 	}
 
 	esCfg := elasticsearch.Config{
@@ -69,6 +59,11 @@ func (c *ElasticSearchClient) Create(ctx context.Context, opts *searchtypes.Inde
 		return nil
 	}
 
+	vector, err := vectorOptions(opts)
+	if err != nil {
+		return err
+	}
+
 	settings := &types.IndexSettings{
 		Search:           &types.SettingsSearch{},
 		NumberOfShards:   opts.Shards,
@@ -80,9 +75,10 @@ func (c *ElasticSearchClient) Create(ctx context.Context, opts *searchtypes.Inde
 		AddProperty("content", esdsl.NewTextProperty()).
 		AddProperty("summary", esdsl.NewTextProperty()).
 		AddProperty("embedding", esdsl.NewDenseVectorProperty().
-			Dims(1024).
+			Dims(vector.dimension).
+			ElementType(densevectorelementtype.DenseVectorElementType{Name: string(vector.element)}).
 			Index(true).
-			Similarity(densevectorsimilarity.Cosine))
+			Similarity(esSimilarity(vector)))
 
 	resp, err := c.client.Indices.
 		Create(c.index).
@@ -100,6 +96,22 @@ func (c *ElasticSearchClient) Create(ctx context.Context, opts *searchtypes.Inde
 	return nil
 }
 
+// esSimilarity maps the metric to Elasticsearch. Bit vectors only support
+// l2_norm, which Elasticsearch computes as hamming distance.
+func esSimilarity(v vectorField) densevectorsimilarity.DenseVectorSimilarity {
+	if v.element == searchtypes.ElementBit {
+		return densevectorsimilarity.L2norm
+	}
+	switch v.metric {
+	case searchtypes.Euclidean:
+		return densevectorsimilarity.L2norm
+	case searchtypes.Dot:
+		return densevectorsimilarity.Dotproduct
+	default:
+		return densevectorsimilarity.Cosine
+	}
+}
+
 func (c *ElasticSearchClient) Insert(ctx context.Context, docs *searchtypes.Document) error {
 	_, err := c.client.
 		Index(c.index).
@@ -8,10 +8,10 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/bexprt/bexgen-client/internal/awsconfig"
 	cfg "github.com/bexprt/bexgen-client/pkg/config"
 	searchtypes "github.com/bexprt/bexgen-client/pkg/database/search/types"
 
-	"github.com/aws/aws-sdk-go-v2/config"
 	opensearch "github.com/opensearch-project/opensearch-go/v4"
 	opensearchapi "github.com/opensearch-project/opensearch-go/v4/opensearchapi"
 	requestsigner "github.com/opensearch-project/opensearch-go/v4/signer/awsv2"
@@ -23,31 +23,28 @@ type OpenSearchClient struct {
 }
 
 type OpenSearchConfig struct {
-	Endpoint string
-	Region   string
-	Index    string
-	Username string
-	Password string
-	IsLocal  bool
+	Endpoint string `yaml:"endpoint" required:"true"`
+	Region   string `yaml:"region"`
+	Index    string `yaml:"index" required:"true"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	IsLocal  bool   `yaml:"isLocal"`
+	// AWS selects the credentials that sign requests to a managed domain.
+	// Its region defaults to Region.
+	AWS awsconfig.Options `yaml:"aws"`
+}
+
+func (c *OpenSearchConfig) Validate() error {
+	if !c.IsLocal && c.Region == "" && c.AWS.Region == "" {
+		return fmt.Errorf("region is required unless isLocal is set")
+	}
+	return nil
 }
 
 func NewClientOpenSearch(ctx context.Context, cfg *cfg.FactoryConfig) (searchtypes.Index, error) {
 	osCfg := &OpenSearchConfig{}
-
-	if len(cfg.Options) > 0 {
-		osCfg.Endpoint = cfg.Options["endpoint"].(string)
-		osCfg.Region = cfg.Options["region"].(string)
-		osCfg.Index = cfg.Options["index"].(string)
-
-		if v, ok := cfg.Options["username"]; ok {
-			osCfg.Username = v.(string)
-		}
-		if v, ok := cfg.Options["password"]; ok {
-			osCfg.Password = v.(string)
-		}
-		if v, ok := cfg.Options["isLocal"]; ok {
-			osCfg.IsLocal = v.(bool)
-		}
+	if err := cfg.DecodeOptions(osCfg); err != nil {
+		return nil, fmt.Errorf("invalid search configuration: %w", err)
 	}
 
 	var client *opensearch.Client
@@ -66,7 +63,12 @@ func NewClientOpenSearch(ctx context.Context, cfg *cfg.FactoryConfig) (searchtyp
 			return nil, err
 		}
 	} else {
-		awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(osCfg.Region))
+		awsOpts := osCfg.AWS
+		if awsOpts.Region == "" {
+			awsOpts.Region = osCfg.Region
+		}
+
+		awsCfg, err := awsconfig.Load(ctx, awsOpts)
 		if err != nil {
 			return nil, err
 		}
@@ -92,6 +94,15 @@ func NewClientOpenSearch(ctx context.Context, cfg *cfg.FactoryConfig) (searchtyp
 }
 
 func (c *OpenSearchClient) Create(ctx context.Context, opts *searchtypes.IndexOptions) error {
+	if opts == nil {
+		return fmt.Errorf("index options cannot be nil")
+	}
+
+	vector, err := vectorOptions(opts)
+	if err != nil {
+		return err
+	}
+
 	body := map[string]any{
 		"settings": map[string]any{
 			"number_of_shards":   opts.Shards,
@@ -100,13 +111,10 @@ func (c *OpenSearchClient) Create(ctx context.Context, opts *searchtypes.IndexOp
 		},
 		"mappings": map[string]any{
 			"properties": map[string]any{
-				"id":      map[string]any{"type": "keyword"},
-				"content": map[string]any{"type": "text"},
-				"summary": map[string]any{"type": "text"},
-				"embedding": map[string]any{
-					"type":      "knn_vector",
-					"dimension": 1024,
-				},
+				"id":        map[string]any{"type": "keyword"},
+				"content":   map[string]any{"type": "text"},
+				"summary":   map[string]any{"type": "text"},
+				"embedding": osVectorMapping(vector),
 			},
 		},
 	}
@@ -119,10 +127,42 @@ func (c *OpenSearchClient) Create(ctx context.Context, opts *searchtypes.IndexOp
 	}
 
 	var resp map[string]any
-	_, err := c.client.Do(ctx, req, &resp)
+	_, err = c.client.Do(ctx, req, &resp)
 	return err
 }
 
+// osVectorMapping builds the knn_vector mapping. Byte vectors need the lucene
+// engine and binary vectors the faiss engine with hamming distance.
+func osVectorMapping(v vectorField) map[string]any {
+	method := map[string]any{"name": "hnsw"}
+	mapping := map[string]any{
+		"type":      "knn_vector",
+		"dimension": v.dimension,
+		"method":    method,
+	}
+
+	switch v.metric {
+	case searchtypes.Euclidean:
+		method["space_type"] = "l2"
+	case searchtypes.Dot:
+		method["space_type"] = "innerproduct"
+	default:
+		method["space_type"] = "cosinesimil"
+	}
+
+	switch v.element {
+	case searchtypes.ElementByte:
+		mapping["data_type"] = "byte"
+		method["engine"] = "lucene"
+	case searchtypes.ElementBit:
+		mapping["data_type"] = "binary"
+		method["engine"] = "faiss"
+		method["space_type"] = "hamming"
+	}
+
+	return mapping
+}
+
 func (c *OpenSearchClient) Insert(ctx context.Context, doc *searchtypes.Document) error {
 	b, _ := json.Marshal(doc)
 
@@ -221,12 +261,22 @@ func (c *OpenSearchClient) VectorSearch(
 	query []float32,
 	opts *searchtypes.SearchOptions,
 ) ([]searchtypes.Result, error) {
+	if len(query) == 0 {
+		return nil, fmt.Errorf("vector query cannot be empty")
+	}
+
+	k := 10
+	if opts != nil && opts.TopK > 0 {
+		k = opts.TopK
+	}
+
 	body := map[string]any{
+		"size": k,
 		"query": map[string]any{
 			"knn": map[string]any{
 				"embedding": map[string]any{
 					"vector": query,
-					"k":      10,
+					"k":      k,
 				},
 			},
 		},
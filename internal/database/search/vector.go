@@ -0,0 +1,50 @@
+package search
+
+import (
+	"fmt"
+
+	searchtypes "github.com/bexprt/bexgen-client/pkg/database/search/types"
+)
+
+type vectorField struct {
+	dimension int
+	element   searchtypes.VectorElementType
+	metric    searchtypes.DistanceMetric
+}
+
+// vectorOptions applies the defaults of the embedding field and checks that
+// the element type supports the requested metric.
+func vectorOptions(opts *searchtypes.IndexOptions) (vectorField, error) {
+	f := vectorField{
+		dimension: opts.Dimension,
+		element:   opts.ElementType,
+		metric:    opts.Metric,
+	}
+	if f.dimension == 0 {
+		f.dimension = searchtypes.DefaultDimension
+	}
+	if f.element == "" {
+		f.element = searchtypes.ElementFloat
+	}
+	if f.metric == "" {
+		f.metric = searchtypes.Cosine
+	}
+
+	switch f.element {
+	case searchtypes.ElementFloat, searchtypes.ElementByte:
+	case searchtypes.ElementBit:
+		if f.dimension%8 != 0 {
+			return f, fmt.Errorf("bit vector dimension must be a multiple of 8, got %d", f.dimension)
+		}
+	default:
+		return f, fmt.Errorf("unsupported vector element type %q", f.element)
+	}
+
+	switch f.metric {
+	case searchtypes.Cosine, searchtypes.Euclidean, searchtypes.Dot:
+	default:
+		return f, fmt.Errorf("unsupported distance metric %q", f.metric)
+	}
+
+	return f, nil
+}
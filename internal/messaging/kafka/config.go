@@ -1,6 +1,7 @@
 package kafka
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -25,104 +26,63 @@ const (
 )
 
 type Config struct {
-	BootstrapServers string
-	SecurityProtocol string
-	SASLMechanism    string
-	SASLUsername     string
-	SASLPassword     string
+	Brokers []string `yaml:"brokers" required:"true"`
+
+	Security struct {
+		Protocol  string `yaml:"protocol"`
+		Mechanism string `yaml:"mechanism"`
+		Username  string `yaml:"username"`
+		Password  string `yaml:"password"`
+	} `yaml:"security"`
 
 	Producer struct {
-		LingerMs  int
-		BatchSize int
-		Ack       string
-		Buffer    int
-		Retries   int
-	}
+		LingerMs  int    `yaml:"linger_ms"`
+		BatchSize int    `yaml:"batch_size"`
+		Ack       string `yaml:"ack"`
+		Buffer    int    `yaml:"buffer_size"`
+		Retries   int    `yaml:"retries"`
+	} `yaml:"producer"`
 
 	Consumer struct {
-		GroupID         string
-		AutoOffsetReset string
-		Buffer          int
-	}
+		GroupID         string `yaml:"group_id"`
+		AutoOffsetReset string `yaml:"auto_offset_reset"`
+		Buffer          int    `yaml:"buffer_size"`
+	} `yaml:"consumer"`
 }
 
-func LoadConfig(cfg *config.FactoryConfig) (*Config, error) {
-	options := cfg.Options
+func (c *Config) Validate() error {
+	var errs []error
 
-	kCfg := &Config{}
-
-	if b, ok := options["brokers"].([]any); ok {
-		strs := make([]string, len(b))
-		for i, v := range b {
-			strs[i] = fmt.Sprintf("%v", v)
-		}
-		kCfg.BootstrapServers = strings.Join(strs, ",")
-	} else {
-		return nil, fmt.Errorf("kafka: brokers must be set in options")
+	if c.Security.Mechanism != "" && (c.Security.Username == "" || c.Security.Password == "") {
+		errs = append(errs, fmt.Errorf("security.username and security.password are required with security.mechanism"))
 	}
 
-	if sec, ok := options["security"].(map[string]any); ok {
-		if p, ok := sec["protocol"].(string); ok {
-			kCfg.SecurityProtocol = p
-		}
-		if m, ok := sec["mechanism"].(string); ok {
-			kCfg.SASLMechanism = m
-		}
-		if u, ok := sec["username"].(string); ok {
-			kCfg.SASLUsername = u
-		}
-		if pw, ok := sec["password"].(string); ok {
-			kCfg.SASLPassword = pw
-		}
+	switch c.Producer.Ack {
+	case "", "all", "-1", "0", "1":
+	default:
+		errs = append(errs, fmt.Errorf("producer.ack must be one of all, -1, 0, 1, got %q", c.Producer.Ack))
 	}
 
-	// producer
-	if prod, ok := options["producer"].(map[string]any); ok {
-		if l, ok := prod["linger_ms"].(int); ok {
-			kCfg.Producer.LingerMs = l
-		} else if l, ok := prod["linger_ms"].(float64); ok {
-			kCfg.Producer.LingerMs = int(l)
-		}
-		if b, ok := prod["batch_size"].(int); ok {
-			kCfg.Producer.BatchSize = b
-		} else if b, ok := prod["batch_size"].(float64); ok {
-			kCfg.Producer.BatchSize = int(b)
-		}
-		if r, ok := prod["retries"].(int); ok {
-			kCfg.Producer.Retries = r
-		} else if b, ok := prod["retries"].(float64); ok {
-			kCfg.Producer.Retries = int(b)
-		}
-		if buf, ok := prod["buffer_size"].(int); ok {
-			kCfg.Producer.Buffer = buf
-		} else if buf, ok := prod["buffer_size"].(float64); ok {
-			kCfg.Producer.Buffer = int(buf)
-		}
-
-		if a, ok := prod["ack"].(string); ok {
-			kCfg.Producer.Ack = a
-		}
+	switch OffsetReset(c.Consumer.AutoOffsetReset) {
+	case "", OffsetEarliest, OffsetLatest, OffsetNone:
+	default:
+		errs = append(errs, fmt.Errorf("consumer.auto_offset_reset must be one of earliest, latest, none, got %q", c.Consumer.AutoOffsetReset))
 	}
 
-	// consumer
-	if cons, ok := options["consumer"].(map[string]any); ok {
-		if g, ok := cons["group_id"].(string); ok {
-			kCfg.Consumer.GroupID = g
-		}
-		if a, ok := cons["auto_offset_reset"].(string); ok {
-			kCfg.Consumer.AutoOffsetReset = a
-		}
-		if b, ok := cons["buffer_size"].(int); ok {
-			kCfg.Consumer.Buffer = b
-		}
-	}
+	return errors.Join(errs...)
+}
 
+func LoadConfig(cfg *config.FactoryConfig) (*Config, error) {
+	kCfg := &Config{}
+	if err := cfg.DecodeOptions(kCfg); err != nil {
+		return nil, fmt.Errorf("kafka: %w", err)
+	}
 	return kCfg, nil
 }
 
 func buildKafkaConfigMap(cfg *Config, clientType KafkaClientType) *kfk.ConfigMap {
 	cm := &kfk.ConfigMap{
-		"bootstrap.servers": cfg.BootstrapServers,
+		"bootstrap.servers": strings.Join(cfg.Brokers, ","),
 	}
 
 	set := func(key string, value any) {
@@ -131,13 +91,13 @@ func buildKafkaConfigMap(cfg *Config, clientType KafkaClientType) *kfk.ConfigMap
 		}
 	}
 
-	if cfg.SecurityProtocol != "" {
-		set("security.protocol", cfg.SecurityProtocol)
+	if cfg.Security.Protocol != "" {
+		set("security.protocol", cfg.Security.Protocol)
 	}
-	if cfg.SASLMechanism != "" {
-		set("sasl.mechanism", cfg.SASLMechanism)
-		set("sasl.username", cfg.SASLUsername)
-		set("sasl.password", cfg.SASLPassword)
+	if cfg.Security.Mechanism != "" {
+		set("sasl.mechanism", cfg.Security.Mechanism)
+		set("sasl.username", cfg.Security.Username)
+		set("sasl.password", cfg.Security.Password)
 	}
 
 	switch clientType {
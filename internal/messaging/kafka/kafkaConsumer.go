@@ -21,6 +21,10 @@ type Consumer[T proto.Message] struct {
 	Msg      proto.Message
 	ctx      context.Context
 	cancel   context.CancelFunc
+
+	// OnError receives consumer, decoding and Nack errors. It defaults to
+	// printing them.
+	OnError func(error)
 }
 
 func NewConsumer[T proto.Message](ctx context.Context, cfg *config.FactoryConfig, topic topics.Topic[T]) (*Consumer[T], error) {
@@ -44,6 +48,9 @@ func NewConsumer[T proto.Message](ctx context.Context, cfg *config.FactoryConfig
 		topic:    topic,
 		ctx:      cctx,
 		cancel:   cancel,
+		OnError: func(err error) {
+			fmt.Printf("kafka consumer: %v\n", err)
+		},
 	}, nil
 }
 
@@ -66,7 +73,7 @@ func (c *Consumer[T]) Open() (<-chan *types.Message[T], error) {
 					if err.(kfk.Error).Code() == kfk.ErrTimedOut {
 						continue
 					}
-					fmt.Printf("Consumer error: %v\n", err)
+					c.OnError(err)
 					return
 				}
 
@@ -78,7 +85,9 @@ func (c *Consumer[T]) Open() (<-chan *types.Message[T], error) {
 				key := string(m.Key)
 				value := c.topic.New()
 				if err := proto.Unmarshal(m.Value, value); err != nil {
-					fmt.Printf("unmarshal error: %v\n", err)
+					if err := c.nack(m, fmt.Errorf("failed to unmarshal message: %w", err)); err != nil {
+						c.OnError(fmt.Errorf("failed to commit rejected message: %w", err))
+					}
 					continue
 				}
 
@@ -90,6 +99,9 @@ func (c *Consumer[T]) Open() (<-chan *types.Message[T], error) {
 						_, err := c.consumer.CommitMessage(m)
 						return err
 					},
+					Nack: func(err error) error {
+						return c.nack(m, err)
+					},
 				}
 			}
 		}
@@ -98,6 +110,19 @@ func (c *Consumer[T]) Open() (<-chan *types.Message[T], error) {
 	return msgChan, nil
 }
 
+// nack reports err and commits past m, since Kafka doesn't redeliver a
+// single message.
+func (c *Consumer[T]) nack(m *kfk.Message, err error) error {
+	c.OnError(fmt.Errorf("rejected message at %s: %w", m.TopicPartition, err))
+	_, cerr := c.consumer.CommitMessage(m)
+	return cerr
+}
+
+// ReportError forwards err to OnError.
+func (c *Consumer[T]) ReportError(err error) {
+	c.OnError(err)
+}
+
 func (c *Consumer[T]) Close() error {
 	c.cancel()
 	if c.consumer != nil {
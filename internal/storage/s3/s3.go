@@ -2,15 +2,14 @@ package s3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 
+	"github.com/bexprt/bexgen-client/internal/awsconfig"
 	cfg "github.com/bexprt/bexgen-client/pkg/config"
 	"github.com/bexprt/bexgen-client/pkg/storage/types"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 )
 
@@ -21,75 +20,60 @@ type Client struct {
 }
 
 type S3Config struct {
-	Bucket    string `yaml:"bucket" mapstructure:"bucket"`
-	Region    string `yaml:"region" mapstructure:"region"`
-	Endpoint  string `yaml:"endpoint" mapstructure:"endpoint"` // For MinIO or custom S3-compatible services
+	Bucket string `yaml:"bucket" mapstructure:"bucket" required:"true"`
+	// Deprecated: use accessKeyId and secretAccessKey. Validate moves them
+	// into AWS.
 	AccessID  string `yaml:"access_id" mapstructure:"access_id"`
 	SecretKey string `yaml:"secret_key" mapstructure:"secret_key"`
+	// AWS holds region, endpoint (for MinIO or custom S3-compatible
+	// services), credentials, profile and role options.
+	AWS awsconfig.Options `yaml:",inline" mapstructure:",squash"`
 }
 
-func NewClient(ctx context.Context, cfg *cfg.FactoryConfig) (types.ObjectStorage, error) {
-	// Parse S3 configuration from options
-	s3Cfg := &S3Config{}
-
-	if len(cfg.Options) > 0 {
-		// If bucket is specified in options, use those values
-		if bucket, ok := cfg.Options["bucket"].(string); ok {
-			s3Cfg.Bucket = bucket
-		}
-		if region, ok := cfg.Options["region"].(string); ok {
-			s3Cfg.Region = region
-		}
-		if endpoint, ok := cfg.Options["endpoint"].(string); ok {
-			s3Cfg.Endpoint = endpoint
-		}
-		if accessID, ok := cfg.Options["access_id"].(string); ok {
-			s3Cfg.AccessID = accessID
-		}
-		if secretKey, ok := cfg.Options["secret_key"].(string); ok {
-			s3Cfg.SecretKey = secretKey
-		}
+func (c *S3Config) Validate() error {
+	var errs []error
+	if (c.AccessID == "") != (c.SecretKey == "") {
+		errs = append(errs, fmt.Errorf("access_id and secret_key must be set together"))
+	}
+	if c.AccessID != "" && c.AWS.AccessKeyID != "" {
+		errs = append(errs, fmt.Errorf("access_id and accessKeyId are mutually exclusive"))
+	}
+	if c.AccessID != "" && c.AWS.Profile != "" {
+		errs = append(errs, fmt.Errorf("profile and access_id are mutually exclusive"))
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
 	}
 
-	// If bucket is not set in config, fail
-	if s3Cfg.Bucket == "" {
-		return nil, fmt.Errorf("bucket name is required in storage configuration")
+	if c.AccessID != "" {
+		c.AWS.AccessKeyID = c.AccessID
+		c.AWS.SecretAccessKey = c.SecretKey
+		c.AccessID, c.SecretKey = "", ""
 	}
+	return nil
+}
 
-	// Set default region if not specified
-	if s3Cfg.Region == "" {
-		s3Cfg.Region = "us-east-1"
+func NewClient(ctx context.Context, cfg *cfg.FactoryConfig) (types.ObjectStorage, error) {
+	// Parse S3 configuration from options
+	s3Cfg := &S3Config{}
+	if err := cfg.DecodeOptions(s3Cfg); err != nil {
+		return nil, fmt.Errorf("invalid storage configuration: %w", err)
 	}
 
-	// Build AWS configuration
-	var awsCfg aws.Config
-	var err error
-
-	if s3Cfg.AccessID != "" && s3Cfg.SecretKey != "" {
-		// Use provided credentials (for MinIO or custom S3)
-		awsCfg, err = config.LoadDefaultConfig(ctx,
-			config.WithRegion(s3Cfg.Region),
-			config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
-				s3Cfg.AccessID,
-				s3Cfg.SecretKey,
-				"",
-			)),
-		)
-	} else {
-		// Use AWS default credentials chain (env vars, IAM role, etc.)
-		awsCfg, err = config.LoadDefaultConfig(ctx,
-			config.WithRegion(s3Cfg.Region),
-		)
+	// Set default region if not specified
+	if s3Cfg.AWS.Region == "" && s3Cfg.AWS.Profile == "" {
+		s3Cfg.AWS.Region = "us-east-1"
 	}
 
+	awsCfg, err := awsconfig.Load(ctx, s3Cfg.AWS)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		return nil, err
 	}
 
-	// Create S3 client with optional custom endpoint
+	// Custom endpoints need path-style addressing
 	clientOptions := func(o *s3.Options) {
-		if s3Cfg.Endpoint != "" {
-			o.BaseEndpoint = aws.String(s3Cfg.Endpoint)
+		if s3Cfg.AWS.Endpoint != "" {
+			o.BaseEndpoint = s3Cfg.AWS.BaseEndpoint()
 			o.UsePathStyle = true // Required for MinIO compatibility
 		}
 	}
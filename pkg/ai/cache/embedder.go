@@ -0,0 +1,259 @@
+// Package cache provides a caching decorator for types.Embedder.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/bexprt/bexgen-client/pkg/ai/types"
+)
+
+const defaultSize = 10000
+
+// Store is a persistent cache tier shared between processes.
+type Store interface {
+	Get(ctx context.Context, keys []string) (map[string][]float32, error)
+	Put(ctx context.Context, entries []Entry) error
+}
+
+type Entry struct {
+	Key       string
+	ModelID   string
+	InputType types.EmbeddingInputType
+	Dimension int
+	Vector    []float32
+}
+
+type Options struct {
+	// ModelID is part of the cache key. It defaults to the ModelID method of
+	// the wrapped embedder when it has one.
+	ModelID string
+	// Size is the number of vectors kept in memory. Defaults to 10000.
+	Size int
+	// Store is an optional second tier consulted on memory misses.
+	Store Store
+}
+
+// Stats counts cache lookups per text.
+type Stats struct {
+	MemoryHits uint64
+	StoreHits  uint64
+	Misses     uint64
+}
+
+// Embedder caches the vectors of another Embedder, keyed by model, input
+// type, dimension and text hash. Returned vectors are shared with the cache
+// and must not be modified.
+type Embedder struct {
+	next    types.Embedder
+	modelID string
+	memory  *lru
+	store   Store
+
+	// OnError receives store lookup and write errors, which never fail the
+	// call. It defaults to printing them.
+	OnError func(error)
+
+	memoryHits atomic.Uint64
+	storeHits  atomic.Uint64
+	misses     atomic.Uint64
+}
+
+var _ types.Embedder = (*Embedder)(nil)
+
+func NewEmbedder(next types.Embedder, opts *Options) (*Embedder, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	modelID := opts.ModelID
+	if modelID == "" {
+		if m, ok := next.(interface{ ModelID() string }); ok {
+			modelID = m.ModelID()
+		}
+	}
+	if modelID == "" {
+		return nil, fmt.Errorf("cache: model id is required")
+	}
+
+	size := opts.Size
+	if size <= 0 {
+		size = defaultSize
+	}
+
+	return &Embedder{
+		next:    next,
+		modelID: modelID,
+		memory:  newLRU(size),
+		store:   opts.Store,
+		OnError: func(err error) {
+			fmt.Printf("%v\n", err)
+		},
+	}, nil
+}
+
+func (e *Embedder) Dimension() int {
+	return e.next.Dimension()
+}
+
+func (e *Embedder) ModelID() string {
+	return e.modelID
+}
+
+func (e *Embedder) Stats() Stats {
+	return Stats{
+		MemoryHits: e.memoryHits.Load(),
+		StoreHits:  e.storeHits.Load(),
+		Misses:     e.misses.Load(),
+	}
+}
+
+// Key returns the cache key of a text.
+func (e *Embedder) Key(text string, inputType types.EmbeddingInputType) string {
+	h := sha256.New()
+	h.Write([]byte(e.modelID))
+	h.Write([]byte{0})
+	h.Write([]byte(inputType))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.Itoa(e.next.Dimension())))
+	h.Write([]byte{0})
+	h.Write([]byte(text))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (e *Embedder) Embed(
+	ctx context.Context,
+	texts []string,
+	embedType types.EmbeddingInputType,
+) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	keys := make([]string, len(texts))
+
+	// missing maps each key not found in memory to the positions using it.
+	missing := map[string][]int{}
+	var order []string
+	for i, t := range texts {
+		keys[i] = e.Key(t, embedType)
+		if v, ok := e.memory.get(keys[i]); ok {
+			vectors[i] = v
+			e.memoryHits.Add(1)
+			continue
+		}
+		if _, ok := missing[keys[i]]; !ok {
+			order = append(order, keys[i])
+		}
+		missing[keys[i]] = append(missing[keys[i]], i)
+	}
+	if len(order) == 0 {
+		return vectors, nil
+	}
+
+	if e.store != nil {
+		found, err := e.store.Get(ctx, order)
+		if err != nil {
+			// The store is only an optimisation, fall back to the model.
+			e.OnError(fmt.Errorf("embedding cache lookup failed: %w", err))
+		}
+		remaining := order[:0]
+		for _, key := range order {
+			v, ok := found[key]
+			if !ok {
+				remaining = append(remaining, key)
+				continue
+			}
+			e.memory.add(key, v)
+			for _, i := range missing[key] {
+				vectors[i] = v
+			}
+			e.storeHits.Add(uint64(len(missing[key])))
+		}
+		order = remaining
+		if len(order) == 0 {
+			return vectors, nil
+		}
+	}
+
+	toEmbed := make([]string, len(order))
+	for j, key := range order {
+		toEmbed[j] = texts[missing[key][0]]
+		e.misses.Add(uint64(len(missing[key])))
+	}
+
+	embedded, err := e.next.Embed(ctx, toEmbed, embedType)
+	var batchErr *types.BatchError
+	if err != nil && !errors.As(err, &batchErr) {
+		return nil, err
+	}
+	if len(embedded) != len(toEmbed) {
+		return nil, fmt.Errorf("embedder returned %d vectors for %d texts", len(embedded), len(toEmbed))
+	}
+
+	var entries []Entry
+	for j, key := range order {
+		v := embedded[j]
+		if v == nil {
+			continue
+		}
+		e.memory.add(key, v)
+		for _, i := range missing[key] {
+			vectors[i] = v
+		}
+		entries = append(entries, Entry{
+			Key:       key,
+			ModelID:   e.modelID,
+			InputType: embedType,
+			Dimension: e.next.Dimension(),
+			Vector:    v,
+		})
+	}
+
+	if e.store != nil && len(entries) > 0 {
+		if err := e.store.Put(ctx, entries); err != nil {
+			e.OnError(fmt.Errorf("embedding cache store failed: %w", err))
+		}
+	}
+
+	if batchErr != nil {
+		return vectors, remapBatchError(batchErr, order, missing, len(texts))
+	}
+
+	return vectors, nil
+}
+
+// EmbedAs caches float embeddings only; other representations are passed
+// through to the wrapped embedder.
+func (e *Embedder) EmbedAs(
+	ctx context.Context,
+	texts []string,
+	embedType types.EmbeddingInputType,
+	as types.EmbeddingType,
+) (*types.Embeddings, error) {
+	if as != types.EmbeddingFloat {
+		return e.next.EmbedAs(ctx, texts, embedType, as)
+	}
+
+	vectors, err := e.Embed(ctx, texts, embedType)
+	if vectors == nil {
+		return nil, err
+	}
+	return &types.Embeddings{Type: types.EmbeddingFloat, Float: vectors}, err
+}
+
+// remapBatchError translates failed positions of the deduplicated request
+// back to the caller's input positions.
+func remapBatchError(err *types.BatchError, order []string, missing map[string][]int, total int) error {
+	out := &types.BatchError{Total: total}
+	for _, f := range err.Failures {
+		var indices []int
+		for _, j := range f.Indices {
+			indices = append(indices, missing[order[j]]...)
+		}
+		out.Failures = append(out.Failures, types.BatchFailure{Indices: indices, Err: f.Err})
+	}
+	return out
+}
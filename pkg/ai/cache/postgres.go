@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/bexprt/bexgen-client/pkg/database/sql"
+)
+
+// PostgresStore keeps vectors in the embedding_cache table.
+type PostgresStore struct {
+	queries db.Querier
+}
+
+var _ Store = (*PostgresStore)(nil)
+
+func NewPostgresStore(queries db.Querier) *PostgresStore {
+	return &PostgresStore{queries: queries}
+}
+
+func (s *PostgresStore) Get(ctx context.Context, keys []string) (map[string][]float32, error) {
+	rows, err := s.queries.GetCachedEmbeddings(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cached embeddings: %w", err)
+	}
+
+	found := make(map[string][]float32, len(rows))
+	for _, r := range rows {
+		found[r.CacheKey] = r.Embedding
+	}
+	return found, nil
+}
+
+func (s *PostgresStore) Put(ctx context.Context, entries []Entry) error {
+	for _, e := range entries {
+		err := s.queries.PutCachedEmbedding(ctx, db.PutCachedEmbeddingParams{
+			CacheKey:  e.Key,
+			ModelID:   e.ModelID,
+			InputType: string(e.InputType),
+			Dimension: int32(e.Dimension),
+			Embedding: e.Vector,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to cache embedding: %w", err)
+		}
+	}
+	return nil
+}
+
+// Prune deletes vectors cached before the given time.
+func (s *PostgresStore) Prune(ctx context.Context, before time.Time) (int64, error) {
+	n, err := s.queries.DeleteCachedEmbeddingsBefore(ctx, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune embedding cache: %w", err)
+	}
+	return n, nil
+}
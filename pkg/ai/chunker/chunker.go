@@ -0,0 +1,287 @@
+// Package chunker splits long texts, such as OCR output, into chunks that fit
+// the token budget of an embedding model.
+package chunker
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	defaultMaxTokens = 512
+
+	// PageBreak separates pages in OCR output.
+	PageBreak = '\f'
+)
+
+type Options struct {
+	// MaxTokens is the token budget of a chunk. Defaults to 512.
+	MaxTokens int
+	// Overlap is the number of tokens repeated from the end of the previous
+	// chunk on the same page. It must be lower than MaxTokens.
+	Overlap int
+	// Tokenizer defaults to HeuristicTokenizer.
+	Tokenizer Tokenizer
+}
+
+// Chunk is a part of the input text. Start and End are byte offsets in the
+// input; Page is 1-based and counts page breaks before Start.
+type Chunk struct {
+	Index  int
+	Text   string
+	Start  int
+	End    int
+	Page   int
+	Tokens int
+}
+
+// ID returns a stable identifier for the chunk of a document.
+func (c Chunk) ID(documentID string) string {
+	return fmt.Sprintf("%s#%d", documentID, c.Index)
+}
+
+// Texts returns the text of each chunk, ready for Embedder.Embed.
+func Texts(chunks []Chunk) []string {
+	texts := make([]string, len(chunks))
+	for i, c := range chunks {
+		texts[i] = c.Text
+	}
+	return texts
+}
+
+type span struct {
+	start, end int
+	tokens     int
+}
+
+type splitter struct {
+	text      string
+	maxTokens int
+	tokenizer Tokenizer
+}
+
+// Split cuts text into chunks of at most MaxTokens tokens. Chunks never
+// cross page breaks. Within a page, paragraphs are kept whole when they fit,
+// then sentences, then words; only words longer than the budget are cut.
+func Split(text string, opts *Options) ([]Chunk, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	s := &splitter{
+		text:      text,
+		maxTokens: opts.MaxTokens,
+		tokenizer: opts.Tokenizer,
+	}
+	if s.maxTokens == 0 {
+		s.maxTokens = defaultMaxTokens
+	}
+	if s.tokenizer == nil {
+		s.tokenizer = HeuristicTokenizer{}
+	}
+	if s.maxTokens < 0 {
+		return nil, fmt.Errorf("max tokens must be positive, got %d", s.maxTokens)
+	}
+	if opts.Overlap < 0 || opts.Overlap >= s.maxTokens {
+		return nil, fmt.Errorf("overlap must be between 0 and %d, got %d", s.maxTokens-1, opts.Overlap)
+	}
+
+	var (
+		chunks []Chunk
+		page   = 1
+		start  = 0
+	)
+	for {
+		end := strings.IndexByte(text[start:], PageBreak)
+		last := end < 0
+		if last {
+			end = len(text)
+		} else {
+			end += start
+		}
+
+		for _, sp := range s.pack(s.pieces(start, end), opts.Overlap) {
+			chunks = append(chunks, Chunk{
+				Index:  len(chunks),
+				Text:   text[sp.start:sp.end],
+				Start:  sp.start,
+				End:    sp.end,
+				Page:   page,
+				Tokens: sp.tokens,
+			})
+		}
+
+		if last {
+			break
+		}
+		start = end + 1
+		page++
+	}
+
+	return chunks, nil
+}
+
+// pieces splits a page into the largest units that fit the budget.
+func (s *splitter) pieces(start, end int) []span {
+	var out []span
+	for _, para := range s.split(start, end, paragraphEnd) {
+		if para.tokens <= s.maxTokens {
+			out = append(out, para)
+			continue
+		}
+		for _, sent := range s.split(para.start, para.end, sentenceEnd) {
+			if sent.tokens <= s.maxTokens {
+				out = append(out, sent)
+				continue
+			}
+			for _, word := range s.split(sent.start, sent.end, wordEnd) {
+				if word.tokens <= s.maxTokens {
+					out = append(out, word)
+					continue
+				}
+				out = append(out, s.cut(word)...)
+			}
+		}
+	}
+	return out
+}
+
+// pack merges consecutive pieces into chunks, starting each chunk with the
+// trailing pieces of the previous one that fit in the overlap. Budgets are
+// checked against the joined text, separators included.
+func (s *splitter) pack(pieces []span, overlap int) []span {
+	var (
+		chunks []span
+		cur    []span
+	)
+
+	count := func(start, end int) int {
+		return s.tokenizer.Count(s.text[start:end])
+	}
+	flush := func() {
+		if len(cur) == 0 {
+			return
+		}
+		start, end := cur[0].start, cur[len(cur)-1].end
+		chunks = append(chunks, span{start: start, end: end, tokens: count(start, end)})
+	}
+
+	for _, p := range pieces {
+		if len(cur) > 0 && count(cur[0].start, p.end) > s.maxTokens {
+			flush()
+
+			last := cur[len(cur)-1].end
+			keep := len(cur)
+			for keep > 0 {
+				start := cur[keep-1].start
+				if count(start, last) > overlap || count(start, p.end) > s.maxTokens {
+					break
+				}
+				keep--
+			}
+			cur = cur[keep:]
+		}
+		cur = append(cur, p)
+	}
+	flush()
+
+	return chunks
+}
+
+// split cuts [start, end) at the positions returned by next and drops the
+// surrounding whitespace of each part.
+func (s *splitter) split(start, end int, next func(text string) int) []span {
+	var out []span
+	for start < end {
+		n := next(s.text[start:end])
+		if n <= 0 {
+			n = end - start
+		}
+		if sp, ok := s.trim(start, start+n); ok {
+			out = append(out, sp)
+		}
+		start += n
+	}
+	return out
+}
+
+// cut splits a single oversized word into runs of runes.
+func (s *splitter) cut(word span) []span {
+	var out []span
+	start := word.start
+	for start < word.end {
+		end, runes := start, 0
+		for end < word.end && runes < s.maxTokens {
+			_, size := utf8.DecodeRuneInString(s.text[end:])
+			end += size
+			runes++
+		}
+		out = append(out, span{start: start, end: end, tokens: s.tokenizer.Count(s.text[start:end])})
+		start = end
+	}
+	return out
+}
+
+func (s *splitter) trim(start, end int) (span, bool) {
+	part := s.text[start:end]
+	trimmed := strings.TrimLeftFunc(part, unicode.IsSpace)
+	start += len(part) - len(trimmed)
+	trimmed = strings.TrimRightFunc(trimmed, unicode.IsSpace)
+	end = start + len(trimmed)
+	if start == end {
+		return span{}, false
+	}
+	return span{start: start, end: end, tokens: s.tokenizer.Count(trimmed)}, true
+}
+
+// paragraphEnd returns the length of the first paragraph including the blank
+// line that ends it.
+func paragraphEnd(text string) int {
+	for i := 0; i < len(text); i++ {
+		if text[i] != '\n' {
+			continue
+		}
+		j := i + 1
+		for j < len(text) && (text[j] == ' ' || text[j] == '\t' || text[j] == '\r') {
+			j++
+		}
+		if j < len(text) && text[j] == '\n' {
+			return j + 1
+		}
+	}
+	return len(text)
+}
+
+// sentenceEnd returns the length of the first sentence: text up to a '.', '!'
+// or '?' followed by whitespace.
+func sentenceEnd(text string) int {
+	for i := 0; i < len(text)-1; i++ {
+		switch text[i] {
+		case '.', '!', '?':
+			j := i + 1
+			// Keep closing quotes and brackets with the sentence.
+			for j < len(text) && strings.IndexByte(`"')]`, text[j]) >= 0 {
+				j++
+			}
+			if j < len(text) && (text[j] == ' ' || text[j] == '\n' || text[j] == '\t' || text[j] == '\r') {
+				return j
+			}
+		}
+	}
+	return len(text)
+}
+
+// wordEnd returns the length of the first word and the whitespace after it.
+func wordEnd(text string) int {
+	i := strings.IndexFunc(text, unicode.IsSpace)
+	if i < 0 {
+		return len(text)
+	}
+	j := strings.IndexFunc(text[i:], func(r rune) bool { return !unicode.IsSpace(r) })
+	if j < 0 {
+		return len(text)
+	}
+	return i + j
+}
@@ -0,0 +1,140 @@
+package chunker
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// wordTokenizer counts one token per word, so budgets are easy to follow.
+type wordTokenizer struct{}
+
+func (wordTokenizer) Count(text string) int {
+	return len(strings.Fields(text))
+}
+
+func TestSplit(t *testing.T) {
+	const words = "one two three four five six seven eight nine ten"
+
+	tests := []struct {
+		name      string
+		text      string
+		maxTokens int
+		overlap   int
+		want      []string
+	}{
+		{
+			name:      "empty",
+			text:      "",
+			maxTokens: 4,
+			want:      []string{},
+		},
+		{
+			name:      "whitespace only",
+			text:      " \n\n\t ",
+			maxTokens: 4,
+			want:      []string{},
+		},
+		{
+			name:      "shorter than one chunk",
+			text:      "  Short text.  ",
+			maxTokens: 4,
+			want:      []string{"Short text."},
+		},
+		{
+			name:      "chunk size",
+			text:      words,
+			maxTokens: 4,
+			want:      []string{"one two three four", "five six seven eight", "nine ten"},
+		},
+		{
+			name:      "overlap",
+			text:      words,
+			maxTokens: 4,
+			overlap:   1,
+			want:      []string{"one two three four", "four five six seven", "seven eight nine ten"},
+		},
+		{
+			name:      "overlap of whole sentences",
+			text:      "A b. C d. E f. G h.",
+			maxTokens: 4,
+			overlap:   2,
+			want:      []string{"A b. C d.", "C d. E f.", "E f. G h."},
+		},
+		{
+			name:      "paragraphs kept whole",
+			text:      "one two.\n\nthree four five.\n\nsix.",
+			maxTokens: 4,
+			want:      []string{"one two.", "three four five.\n\nsix."},
+		},
+		{
+			name:      "page breaks",
+			text:      "one two\fthree",
+			maxTokens: 4,
+			want:      []string{"one two", "three"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks, err := Split(tt.text, &Options{
+				MaxTokens: tt.maxTokens,
+				Overlap:   tt.overlap,
+				Tokenizer: wordTokenizer{},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := Texts(chunks); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Split = %q, want %q", got, tt.want)
+			}
+
+			for i, c := range chunks {
+				if c.Index != i {
+					t.Errorf("chunk %d has Index %d", i, c.Index)
+				}
+				if tt.text[c.Start:c.End] != c.Text {
+					t.Errorf("chunk %d offsets [%d, %d) don't match its text", i, c.Start, c.End)
+				}
+				if want := (wordTokenizer{}).Count(c.Text); c.Tokens != want {
+					t.Errorf("chunk %d Tokens = %d, want %d", i, c.Tokens, want)
+				}
+				if c.Tokens > tt.maxTokens {
+					t.Errorf("chunk %d has %d tokens, over the budget of %d", i, c.Tokens, tt.maxTokens)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitPages(t *testing.T) {
+	chunks, err := Split("one\ftwo\f\fthree", &Options{MaxTokens: 4, Tokenizer: wordTokenizer{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var pages []int
+	for _, c := range chunks {
+		pages = append(pages, c.Page)
+	}
+	if want := []int{1, 2, 4}; !reflect.DeepEqual(pages, want) {
+		t.Fatalf("pages = %v, want %v", pages, want)
+	}
+}
+
+func TestSplitInvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{"negative max tokens", Options{MaxTokens: -1}},
+		{"negative overlap", Options{Overlap: -1}},
+		{"overlap not below max tokens", Options{MaxTokens: 4, Overlap: 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Split("text", &tt.opts); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
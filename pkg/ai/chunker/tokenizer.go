@@ -0,0 +1,28 @@
+package chunker
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// Tokenizer counts the tokens of a text for the target model.
+type Tokenizer interface {
+	Count(text string) int
+}
+
+// HeuristicTokenizer estimates token counts without a vocabulary. It assumes
+// CharsPerToken characters per token, but never fewer tokens than words.
+type HeuristicTokenizer struct {
+	// CharsPerToken defaults to 4, a fair average for English text.
+	CharsPerToken float64
+}
+
+func (t HeuristicTokenizer) Count(text string) int {
+	cpt := t.CharsPerToken
+	if cpt <= 0 {
+		cpt = 4
+	}
+	byChars := int(math.Ceil(float64(utf8.RuneCountInString(text)) / cpt))
+	return max(byChars, len(strings.Fields(text)))
+}
@@ -0,0 +1,343 @@
+// Package classify picks the category and subcategory of a document by
+// embedding similarity, and asks a model to decide between the candidates
+// when the similarity match is not confident enough.
+package classify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	classificationv1 "github.com/bexprt/bexgen-client/pb/classification/v1"
+	"github.com/bexprt/bexgen-client/pkg/ai/prompt"
+	"github.com/bexprt/bexgen-client/pkg/ai/structured"
+	"github.com/bexprt/bexgen-client/pkg/ai/types"
+)
+
+const (
+	defaultThreshold = 0.5
+	defaultMaxChars  = 8000
+)
+
+type Method string
+
+const (
+	MethodEmbedding Method = "embedding"
+	MethodModel     Method = "model"
+)
+
+type Options struct {
+	// Threshold is the similarity under which the model is asked. Defaults
+	// to 0.5; a negative value never asks the model.
+	Threshold float32
+	// Margin also asks the model when the two best categories are closer
+	// than Margin, even above Threshold.
+	Margin float32
+	// MaxChars limits the document text sent to the embedder and the model.
+	// Defaults to 8000.
+	MaxChars int
+	// Prompts defaults to prompt.Default().
+	Prompts       *prompt.Registry
+	PromptVersion string
+	Inference     types.InferenceConfig
+}
+
+// Match is a category and subcategory with the confidence of the choice.
+type Match struct {
+	Category    string
+	Subcategory string
+	Confidence  float32
+}
+
+type Result struct {
+	Match
+	Method Method
+	// Reason explains the choice: the similarity scores for embedding
+	// matches, the model's explanation otherwise.
+	Reason string
+	// Embedding is the similarity match, also when the model decided.
+	Embedding Match
+}
+
+// Response converts the result to the classifier service response.
+func (r *Result) Response() *classificationv1.ClassifyResponse {
+	return &classificationv1.ClassifyResponse{
+		Category:   r.Category,
+		Label:      r.Subcategory,
+		Confidence: r.Confidence,
+	}
+}
+
+type Classifier struct {
+	embedder types.Embedder
+	model    types.Model
+	opts     Options
+}
+
+// New builds a classifier. model may be nil to only use similarity.
+func New(embedder types.Embedder, model types.Model, opts Options) (*Classifier, error) {
+	if embedder == nil {
+		return nil, fmt.Errorf("classifier requires an embedder")
+	}
+	if opts.Threshold == 0 {
+		opts.Threshold = defaultThreshold
+	}
+	if opts.MaxChars == 0 {
+		opts.MaxChars = defaultMaxChars
+	}
+	if model != nil {
+		if opts.Prompts == nil {
+			prompts, err := prompt.Default()
+			if err != nil {
+				return nil, fmt.Errorf("failed to load default prompts: %w", err)
+			}
+			opts.Prompts = prompts
+		}
+		if _, err := opts.Prompts.Get(prompt.Classify, opts.PromptVersion); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Classifier{embedder: embedder, model: model, opts: opts}, nil
+}
+
+// Classify chooses among the categories and subcategories of req, which
+// must carry embeddings from the classifier's embedding model.
+func (c *Classifier) Classify(ctx context.Context, req *classificationv1.ClassifyRequest) (*Result, error) {
+	if len(req.GetCategories()) == 0 {
+		return nil, fmt.Errorf("no categories to choose from")
+	}
+	text := truncate(req.GetText(), c.opts.MaxChars)
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("nothing to classify")
+	}
+
+	vectors, err := c.embedder.Embed(ctx, []string{text}, types.InputClassification)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed document: %w", err)
+	}
+
+	match, runnerUp, err := bestMatch(vectors[0], req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Result{
+		Match:     match,
+		Method:    MethodEmbedding,
+		Embedding: match,
+		Reason:    fmt.Sprintf("similarity %.3f to %q", match.Confidence, match.Category),
+	}
+	if runnerUp != nil {
+		res.Reason += fmt.Sprintf(", next best %.3f to %q", runnerUp.Confidence, runnerUp.Category)
+	}
+
+	ambiguous := match.Confidence < c.opts.Threshold ||
+		(runnerUp != nil && match.Confidence-runnerUp.Confidence < c.opts.Margin)
+	if c.model == nil || c.opts.Threshold < 0 || !ambiguous {
+		return res, nil
+	}
+
+	decision, err := c.ask(ctx, text, req)
+	if err != nil {
+		// The similarity match is still an answer, keep it with the reason
+		// the fallback didn't apply.
+		res.Reason += fmt.Sprintf("; model fallback failed: %v", err)
+		return res, nil
+	}
+
+	res.Match = decision.Match
+	res.Method = MethodModel
+	res.Reason = decision.Reason
+	return res, nil
+}
+
+type candidate struct {
+	Name          string
+	Description   string
+	Subcategories []candidate
+}
+
+type answer struct {
+	Category    string  `json:"category"`
+	Subcategory string  `json:"subcategory,omitempty"`
+	Confidence  float32 `json:"confidence"`
+	Reason      string  `json:"reason"`
+}
+
+type decision struct {
+	Match
+	Reason string
+}
+
+// ask lets the model choose among the candidate names. Its answer is
+// matched back to the candidates, so names outside of them are rejected.
+func (c *Classifier) ask(ctx context.Context, text string, req *classificationv1.ClassifyRequest) (*decision, error) {
+	var (
+		candidates    []candidate
+		categories    []string
+		subcategories []string
+	)
+	for _, cat := range req.GetCategories() {
+		cand := candidate{Name: cat.GetName(), Description: cat.GetDescription()}
+		for _, sub := range req.GetSubcategories() {
+			if sub.GetCategory() == cat.GetName() {
+				cand.Subcategories = append(cand.Subcategories, candidate{Name: sub.GetName(), Description: sub.GetDescription()})
+				subcategories = append(subcategories, sub.GetName())
+			}
+		}
+		candidates = append(candidates, cand)
+		categories = append(categories, cat.GetName())
+	}
+
+	p, err := c.opts.Prompts.Render(prompt.Classify, c.opts.PromptVersion, map[string]any{
+		"Text":              text,
+		"Categories":        candidates,
+		"WithSubcategories": len(subcategories) > 0,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := answerSchema(categories, subcategories)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := structured.Generate[answer](p.Context(ctx), c.model, p.Text, &structured.Options{
+		Schema:    schema,
+		Inference: c.opts.Inference,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d := &decision{Reason: strings.TrimSpace(a.Reason)}
+	var ok bool
+	if d.Category, ok = lookup(categories, a.Category); !ok {
+		return nil, fmt.Errorf("model chose unknown category %q", a.Category)
+	}
+	if a.Subcategory != "" {
+		for _, sub := range req.GetSubcategories() {
+			if sub.GetCategory() == d.Category && strings.EqualFold(sub.GetName(), strings.TrimSpace(a.Subcategory)) {
+				d.Subcategory = sub.GetName()
+			}
+		}
+	}
+	d.Confidence = min(max(a.Confidence, 0), 1)
+	return d, nil
+}
+
+func answerSchema(categories, subcategories []string) (json.RawMessage, error) {
+	sub := map[string]any{
+		"type":        "string",
+		"description": "Subcategory of the chosen category, empty if none fits.",
+	}
+	if len(subcategories) > 0 {
+		sub["enum"] = append([]string{""}, subcategories...)
+	}
+
+	return json.Marshal(map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"category":    map[string]any{"type": "string", "enum": categories},
+			"subcategory": sub,
+			"confidence": map[string]any{
+				"type":        "number",
+				"description": "Confidence in the choice, between 0 and 1.",
+			},
+			"reason": map[string]any{
+				"type":        "string",
+				"description": "One sentence explaining the choice.",
+			},
+		},
+		"required":             []string{"category", "confidence", "reason"},
+		"additionalProperties": false,
+	})
+}
+
+// bestMatch returns the most similar category with its best subcategory,
+// and the second most similar category if any.
+func bestMatch(vector []float32, req *classificationv1.ClassifyRequest) (Match, *Match, error) {
+	var (
+		best, second Match
+		found        int
+	)
+	for _, cat := range req.GetCategories() {
+		if len(cat.GetEmbedding()) == 0 {
+			continue
+		}
+		score, err := cosine(vector, cat.GetEmbedding())
+		if err != nil {
+			return Match{}, nil, fmt.Errorf("category %q: %w", cat.GetName(), err)
+		}
+		m := Match{Category: cat.GetName(), Confidence: score}
+		switch {
+		case found == 0 || score > best.Confidence:
+			second, best = best, m
+		case found == 1 || score > second.Confidence:
+			second = m
+		}
+		found++
+	}
+	if found == 0 {
+		return Match{}, nil, fmt.Errorf("no category has an embedding")
+	}
+
+	var subScore float32 = -1
+	for _, sub := range req.GetSubcategories() {
+		if sub.GetCategory() != best.Category || len(sub.GetEmbedding()) == 0 {
+			continue
+		}
+		score, err := cosine(vector, sub.GetEmbedding())
+		if err != nil {
+			return Match{}, nil, fmt.Errorf("subcategory %q: %w", sub.GetName(), err)
+		}
+		if score > subScore {
+			best.Subcategory, subScore = sub.GetName(), score
+		}
+	}
+
+	if found == 1 {
+		return best, nil, nil
+	}
+	return best, &second, nil
+}
+
+// cosine returns the cosine similarity of a and b clamped to [0, 1], the
+// range of ClassifyResponse.Confidence.
+func cosine(a, b []float32) (float32, error) {
+	if len(a) != len(b) {
+		return 0, fmt.Errorf("embedding has %d dimensions, expected %d", len(b), len(a))
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0, nil
+	}
+	return float32(min(max(dot/(math.Sqrt(na)*math.Sqrt(nb)), 0), 1)), nil
+}
+
+func lookup(names []string, name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return n, true
+		}
+	}
+	return "", false
+}
+
+func truncate(text string, maxChars int) string {
+	if utf8.RuneCountInString(text) <= maxChars {
+		return text
+	}
+	return string([]rune(text)[:maxChars])
+}
@@ -2,59 +2,113 @@ package ai
 
 import (
 	"context"
-	"fmt"
 
 	cohereembedding "github.com/bexprt/bexgen-client/internal/ai/cohere-embedding"
+	coherererank "github.com/bexprt/bexgen-client/internal/ai/cohere-rerank"
+	"github.com/bexprt/bexgen-client/internal/ai/fake"
 	novapro "github.com/bexprt/bexgen-client/internal/ai/nova-pro"
+	"github.com/bexprt/bexgen-client/internal/ai/openai"
+	"github.com/bexprt/bexgen-client/internal/ai/router"
 	"github.com/bexprt/bexgen-client/pkg/ai/types"
 	"github.com/bexprt/bexgen-client/pkg/config"
+	"github.com/bexprt/bexgen-client/pkg/registry"
 )
 
+type (
+	// EmbedderConstructor builds an Embedder from the embedding section.
+	EmbedderConstructor func(ctx context.Context, cfg *config.FactoryConfig) (types.Embedder, error)
+	// ModelConstructor builds a Model from the model section.
+	ModelConstructor func(ctx context.Context, cfg *config.FactoryConfig) (types.Model, error)
+	// RerankerConstructor builds a Rerank from the rerank section.
+	RerankerConstructor func(ctx context.Context, cfg *config.FactoryConfig) (types.Rerank, error)
+)
+
+var (
+	embedders = registry.New[EmbedderConstructor](config.SectionEmbedding)
+	models    = registry.New[ModelConstructor](config.SectionModel)
+	rerankers = registry.New[RerankerConstructor](config.SectionRerank)
+)
+
+func init() {
+	RegisterEmbedder("cohere", cohereembedding.NewBedrockCohereEmbedder)
+	RegisterModel("nova-pro", novapro.New)
+	RegisterReranker("cohere", coherererank.New)
+	RegisterEmbedder("openai-compatible", openai.NewEmbedder)
+	RegisterModel("openai-compatible", openai.NewModel)
+	RegisterEmbedder("fake", fake.NewEmbedder)
+	RegisterModel("fake", fake.NewModel)
+	RegisterReranker("fake", fake.NewReranker)
+	RegisterModel("router", router.NewConstructor(newModel))
+
+	config.RegisterOptions(config.SectionEmbedding, "cohere", func() any { return &cohereembedding.Options{} })
+	config.RegisterOptions(config.SectionModel, "nova-pro", func() any { return &novapro.Options{} })
+	config.RegisterOptions(config.SectionRerank, "cohere", func() any { return &coherererank.Options{} })
+	config.RegisterOptions(config.SectionEmbedding, "openai-compatible", func() any { return &openai.EmbedderOptions{} })
+	config.RegisterOptions(config.SectionModel, "openai-compatible", func() any { return &openai.ModelOptions{} })
+	config.RegisterOptions(config.SectionEmbedding, "fake", func() any { return &fake.EmbedderOptions{} })
+	config.RegisterOptions(config.SectionModel, "fake", func() any { return &fake.ModelOptions{} })
+	config.RegisterOptions(config.SectionRerank, "fake", func() any { return &fake.RerankOptions{} })
+	config.RegisterOptions(config.SectionModel, "router", func() any { return &router.Options{} })
+}
+
+// RegisterEmbedder makes an Embedder driver available to NewEmbedder. Drivers
+// with typed options should also call config.RegisterOptions.
+func RegisterEmbedder(driver string, constructor EmbedderConstructor) {
+	embedders.Register(driver, constructor)
+}
+
+// RegisterModel makes a Model driver available to NewModelClient.
+func RegisterModel(driver string, constructor ModelConstructor) {
+	models.Register(driver, constructor)
+}
+
+// RegisterReranker makes a Rerank driver available to NewReranker.
+func RegisterReranker(driver string, constructor RerankerConstructor) {
+	rerankers.Register(driver, constructor)
+}
+
+// EmbedderDrivers returns the names of the registered embedding drivers.
+func EmbedderDrivers() []string {
+	return embedders.Drivers()
+}
+
+// ModelDrivers returns the names of the registered model drivers.
+func ModelDrivers() []string {
+	return models.Drivers()
+}
+
+// RerankerDrivers returns the names of the registered rerank drivers.
+func RerankerDrivers() []string {
+	return rerankers.Drivers()
+}
+
 // TODO: move all ai implemntaion to langchain
 func NewEmbedder(ctx context.Context, cfg *config.RootYAML) (types.Embedder, error) {
-	if cfg.Embedding == nil {
-		return nil, fmt.Errorf("embedding config not found")
-	}
-	if cfg.Embedding.Driver == "" {
-		return nil, fmt.Errorf("embedding.driver is required")
-	}
-	modelID, ok := cfg.Embedding.Options["modelId"].(string)
-	if !ok {
-		return nil, fmt.Errorf("modelId is required")
-	}
-
-	switch cfg.Embedding.Driver {
-	case "cohere":
-		return cohereembedding.NewBedrockCohereEmbedder(ctx, modelID, cfg.Embedding)
-	default:
-		return nil, fmt.Errorf("unsupported driver: %s", cfg.Embedding.Driver)
+	constructor, err := embedders.Lookup(cfg.Embedding)
+	if err != nil {
+		return nil, err
 	}
+	return constructor(ctx, cfg.Embedding)
 }
 
 func NewModelClient(ctx context.Context, cfg *config.RootYAML) (types.Model, error) {
-	if cfg.Model == nil {
-		return nil, fmt.Errorf("embedding config not found")
-	}
-	if cfg.Model.Driver == "" {
-		return nil, fmt.Errorf("embedding.driver is required")
-	}
-	modelID, ok := cfg.Model.Options["modelId"].(string)
-	if !ok {
-		return nil, fmt.Errorf("modelId is required")
-	}
-	maxTokens, ok := cfg.Model.Options["maxTokens"].(int)
-	if !ok {
-		return nil, fmt.Errorf("maxTokens is required")
-	}
-	temperature, ok := cfg.Model.Options["temperature"].(float32)
-	if !ok {
-		return nil, fmt.Errorf("temperature is required")
+	return newModel(ctx, cfg.Model)
+}
+
+// newModel builds a model from its section, also for the backends of the
+// router driver.
+func newModel(ctx context.Context, cfg *config.FactoryConfig) (types.Model, error) {
+	constructor, err := models.Lookup(cfg)
+	if err != nil {
+		return nil, err
 	}
+	return constructor(ctx, cfg)
+}
 
-	switch cfg.Model.Driver {
-	case "nova-pro":
-		return novapro.New(ctx, modelID, maxTokens, temperature)
-	default:
-		return nil, fmt.Errorf("unsupported driver: %s", cfg.Embedding.Driver)
+func NewReranker(ctx context.Context, cfg *config.RootYAML) (types.Rerank, error) {
+	constructor, err := rerankers.Lookup(cfg.Rerank)
+	if err != nil {
+		return nil, err
 	}
+	return constructor(ctx, cfg.Rerank)
 }
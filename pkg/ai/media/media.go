@@ -0,0 +1,119 @@
+// Package media loads the images and documents referenced by model requests
+// from object storage, so drivers only ever see file content.
+package media
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/bexprt/bexgen-client/pkg/ai/types"
+	storagetypes "github.com/bexprt/bexgen-client/pkg/storage/types"
+)
+
+// maxBytes is the Bedrock request size limit, no single file can exceed it.
+const maxBytes = 25 << 20
+
+// Resolve returns req with the content of every image and document block
+// that only has a Path loaded from store. req itself is left unchanged.
+func Resolve(ctx context.Context, req *types.Request, store storagetypes.ObjectStorage) (*types.Request, error) {
+	if req == nil || !hasPaths(req) {
+		return req, nil
+	}
+	if store == nil {
+		return nil, fmt.Errorf("request references stored media but no storage is configured")
+	}
+
+	out := *req
+	out.Messages = make([]types.Message, len(req.Messages))
+	for i, m := range req.Messages {
+		out.Messages[i] = types.Message{Role: m.Role, Content: make([]types.ContentBlock, len(m.Content))}
+		for j, c := range m.Content {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			var err error
+			if c.Image, err = load(c.Image, store); err != nil {
+				return nil, fmt.Errorf("messages[%d].content[%d]: %w", i, j, err)
+			}
+			if c.Document, err = load(c.Document, store); err != nil {
+				return nil, fmt.Errorf("messages[%d].content[%d]: %w", i, j, err)
+			}
+			out.Messages[i].Content[j] = c
+		}
+	}
+	return &out, nil
+}
+
+func hasPaths(req *types.Request) bool {
+	for _, m := range req.Messages {
+		for _, c := range m.Content {
+			if unresolved(c.Image) || unresolved(c.Document) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func unresolved(m *types.Media) bool {
+	return m != nil && len(m.Data) == 0 && m.Path != ""
+}
+
+func load(m *types.Media, store storagetypes.ObjectStorage) (*types.Media, error) {
+	if !unresolved(m) {
+		return m, nil
+	}
+
+	r, err := store.Get(m.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", m.Path, err)
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(io.LimitReader(r, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", m.Path, err)
+	}
+	if len(data) > maxBytes {
+		return nil, fmt.Errorf("%s exceeds %d bytes", m.Path, maxBytes)
+	}
+
+	resolved := *m
+	resolved.Data = data
+	if resolved.Format == "" {
+		resolved.Format = types.FormatOf(m.Path)
+	}
+	if resolved.Name == "" {
+		resolved.Name = path.Base(m.Path)
+	}
+	return &resolved, nil
+}
+
+// WithStorage wraps model so that stored media in Converse and
+// ConverseStream requests are resolved from store first.
+func WithStorage(model types.Model, store storagetypes.ObjectStorage) types.Model {
+	return &resolvingModel{Model: model, store: store}
+}
+
+type resolvingModel struct {
+	types.Model
+	store storagetypes.ObjectStorage
+}
+
+func (m *resolvingModel) Converse(ctx context.Context, req *types.Request) (*types.Response, error) {
+	req, err := Resolve(ctx, req, m.store)
+	if err != nil {
+		return nil, err
+	}
+	return m.Model.Converse(ctx, req)
+}
+
+func (m *resolvingModel) ConverseStream(ctx context.Context, req *types.Request) (<-chan types.StreamEvent, error) {
+	req, err := Resolve(ctx, req, m.store)
+	if err != nil {
+		return nil, err
+	}
+	return m.Model.ConverseStream(ctx, req)
+}
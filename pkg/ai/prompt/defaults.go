@@ -0,0 +1,31 @@
+package prompt
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// Names of the prompts shipped with the module.
+const (
+	SummarizeChunk  = "summarize-chunk"
+	SummarizeReduce = "summarize-reduce"
+	Classify        = "classify"
+	ExtractFields   = "extract-fields"
+)
+
+//go:embed prompts
+var embedded embed.FS
+
+// Default returns a registry holding the prompts shipped with the module.
+// Directories and stores loaded into it afterwards override them.
+func Default() (*Registry, error) {
+	sub, err := fs.Sub(embedded, "prompts")
+	if err != nil {
+		return nil, err
+	}
+	r := NewRegistry()
+	if err := r.LoadFS(sub); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
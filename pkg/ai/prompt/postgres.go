@@ -0,0 +1,61 @@
+package prompt
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/bexprt/bexgen-client/pkg/database/sql"
+)
+
+// Store is a source of templates managed outside the binary.
+type Store interface {
+	List(ctx context.Context) ([]*Template, error)
+}
+
+// PostgresStore keeps templates in the prompt_templates table.
+type PostgresStore struct {
+	queries db.Querier
+}
+
+var _ Store = (*PostgresStore)(nil)
+
+func NewPostgresStore(queries db.Querier) *PostgresStore {
+	return &PostgresStore{queries: queries}
+}
+
+func (s *PostgresStore) List(ctx context.Context) ([]*Template, error) {
+	rows, err := s.queries.ListPromptTemplates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompt templates: %w", err)
+	}
+
+	templates := make([]*Template, 0, len(rows))
+	for _, row := range rows {
+		t, err := New(row.Name, row.Version, row.Template, row.Required)
+		if err != nil {
+			return nil, err
+		}
+		t.Description = row.Description
+		templates = append(templates, t)
+	}
+	return templates, nil
+}
+
+// Save stores a template, replacing the one with the same name and version.
+func (s *PostgresStore) Save(ctx context.Context, t *Template) error {
+	required := t.Required
+	if required == nil {
+		required = []string{}
+	}
+	err := s.queries.UpsertPromptTemplate(ctx, db.UpsertPromptTemplateParams{
+		Name:        t.Name,
+		Version:     t.Version,
+		Description: t.Description,
+		Required:    required,
+		Template:    t.Source,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save prompt %s@%s: %w", t.Name, t.Version, err)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package prompt
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const templateExt = ".tmpl"
+
+// Registry holds templates by name and version. It is safe for concurrent
+// use, so templates can be reloaded while services render them.
+type Registry struct {
+	mu        sync.RWMutex
+	templates map[string]map[string]*Template
+}
+
+func NewRegistry() *Registry {
+	return &Registry{templates: map[string]map[string]*Template{}}
+}
+
+// Add registers a template, replacing any template with the same name and
+// version. Sources loaded later therefore override earlier ones.
+func (r *Registry) Add(t *Template) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.templates[t.Name] == nil {
+		r.templates[t.Name] = map[string]*Template{}
+	}
+	r.templates[t.Name][t.Version] = t
+}
+
+// LoadFS adds every <name>/<version>.tmpl file of fsys.
+func (r *Registry) LoadFS(fsys fs.FS) error {
+	var loaded []*Template
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || path.Ext(p) != templateExt {
+			return nil
+		}
+
+		dir, file := path.Split(p)
+		name := path.Base(dir)
+		if dir == "" || name == "." {
+			return fmt.Errorf("prompt %s must be stored as <name>/<version>%s", p, templateExt)
+		}
+		version := strings.TrimSuffix(file, templateExt)
+
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return fmt.Errorf("failed to read prompt %s: %w", p, err)
+		}
+		t, err := Parse(name, version, data)
+		if err != nil {
+			return err
+		}
+		loaded = append(loaded, t)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, t := range loaded {
+		r.Add(t)
+	}
+	return nil
+}
+
+// LoadDir adds the templates stored under dir.
+func (r *Registry) LoadDir(dir string) error {
+	return r.LoadFS(os.DirFS(dir))
+}
+
+// Load adds the templates of a store.
+func (r *Registry) Load(ctx context.Context, store Store) error {
+	templates, err := store.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, t := range templates {
+		r.Add(t)
+	}
+	return nil
+}
+
+// Get returns a template. An empty version selects the latest one.
+func (r *Registry) Get(name, version string) (*Template, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions, ok := r.templates[name]
+	if !ok {
+		return nil, fmt.Errorf("prompt %q not found", name)
+	}
+	if version == "" {
+		version = latest(versions)
+	}
+	t, ok := versions[version]
+	if !ok {
+		return nil, fmt.Errorf("prompt %s@%s not found", name, version)
+	}
+	return t, nil
+}
+
+// Versions returns the versions of a template, oldest first.
+func (r *Registry) Versions(name string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions := make([]string, 0, len(r.templates[name]))
+	for v := range r.templates[name] {
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return compareVersions(versions[i], versions[j]) < 0
+	})
+	return versions
+}
+
+// Render renders a template, see Get and Template.Render.
+func (r *Registry) Render(name, version string, vars any) (Rendered, error) {
+	t, err := r.Get(name, version)
+	if err != nil {
+		return Rendered{}, err
+	}
+	return t.Render(vars)
+}
+
+func latest(versions map[string]*Template) string {
+	var best string
+	for v := range versions {
+		if best == "" || compareVersions(v, best) > 0 {
+			best = v
+		}
+	}
+	return best
+}
+
+// compareVersions orders versions such as "v2", "1.10.0" or "2024-06-01"
+// by comparing their dot or dash separated parts numerically where both
+// parts are numbers.
+func compareVersions(a, b string) int {
+	split := func(s string) []string {
+		s = strings.TrimPrefix(strings.ToLower(s), "v")
+		return strings.FieldsFunc(s, func(r rune) bool { return r == '.' || r == '-' })
+	}
+
+	pa, pb := split(a), split(b)
+	for i := 0; i < len(pa) && i < len(pb); i++ {
+		na, errA := strconv.Atoi(pa[i])
+		nb, errB := strconv.Atoi(pb[i])
+		switch {
+		case errA == nil && errB == nil:
+			if na != nb {
+				if na < nb {
+					return -1
+				}
+				return 1
+			}
+		case pa[i] != pb[i]:
+			return strings.Compare(pa[i], pb[i])
+		}
+	}
+	switch {
+	case len(pa) < len(pb):
+		return -1
+	case len(pa) > len(pb):
+		return 1
+	}
+	return strings.Compare(a, b)
+}
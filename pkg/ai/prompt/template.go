@@ -0,0 +1,164 @@
+// Package prompt keeps named, versioned prompt templates. Templates use Go
+// text/template syntax and are loaded from an fs.FS, a directory or the
+// prompt_templates table; rendering checks that the required variables are
+// set and the result carries the name and version for usage records.
+package prompt
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/bexprt/bexgen-client/pkg/ai/usage"
+	"gopkg.in/yaml.v3"
+)
+
+const frontMatterDelimiter = "---"
+
+// funcs are available to every template in addition to the text/template
+// builtins.
+var funcs = template.FuncMap{
+	"add":   func(a, b int) int { return a + b },
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+}
+
+type Template struct {
+	Name        string
+	Version     string
+	Description string
+	// Required lists the variables Render refuses to run without.
+	Required []string
+	Source   string
+
+	tmpl *template.Template
+}
+
+// frontMatter is the optional YAML header of a template file:
+//
+//	---
+//	description: Summarizes one chunk of a document.
+//	required: [Text]
+//	---
+type frontMatter struct {
+	Description string   `yaml:"description"`
+	Required    []string `yaml:"required"`
+}
+
+// New parses source as a template.
+func New(name, version, source string, required []string) (*Template, error) {
+	if name == "" || version == "" {
+		return nil, fmt.Errorf("prompt name and version are required")
+	}
+
+	tmpl, err := template.New(name + "@" + version).Funcs(funcs).Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse prompt %s@%s: %w", name, version, err)
+	}
+
+	return &Template{
+		Name:     name,
+		Version:  version,
+		Required: required,
+		Source:   source,
+		tmpl:     tmpl,
+	}, nil
+}
+
+// Parse parses a template file, reading the description and required
+// variables from its front matter when present.
+func Parse(name, version string, data []byte) (*Template, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+
+	var fm frontMatter
+	if rest, ok := strings.CutPrefix(text, frontMatterDelimiter+"\n"); ok {
+		header, body, found := strings.Cut(rest, "\n"+frontMatterDelimiter+"\n")
+		if !found {
+			return nil, fmt.Errorf("prompt %s@%s: unterminated front matter", name, version)
+		}
+		if err := yaml.Unmarshal([]byte(header), &fm); err != nil {
+			return nil, fmt.Errorf("prompt %s@%s: invalid front matter: %w", name, version, err)
+		}
+		text = body
+	}
+
+	t, err := New(name, version, text, fm.Required)
+	if err != nil {
+		return nil, err
+	}
+	t.Description = fm.Description
+	return t, nil
+}
+
+// Rendered is a prompt ready to send, with the template it came from.
+type Rendered struct {
+	Name    string
+	Version string
+	Text    string
+}
+
+// Context attributes the AI calls made with the returned context to the
+// prompt, so the usage records show which template produced them.
+func (r Rendered) Context(ctx context.Context) context.Context {
+	return usage.WithPrompt(ctx, r.Name, r.Version)
+}
+
+// Render executes the template. vars is usually a map[string]any; a struct
+// works as well, with required variables matched against its field names.
+func (t *Template) Render(vars any) (Rendered, error) {
+	if missing := t.missing(vars); len(missing) > 0 {
+		return Rendered{}, fmt.Errorf("prompt %s@%s: missing required variables: %s",
+			t.Name, t.Version, strings.Join(missing, ", "))
+	}
+
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, vars); err != nil {
+		return Rendered{}, fmt.Errorf("failed to render prompt %s@%s: %w", t.Name, t.Version, err)
+	}
+
+	return Rendered{
+		Name:    t.Name,
+		Version: t.Version,
+		Text:    strings.TrimSpace(buf.String()),
+	}, nil
+}
+
+// missing returns the required variables that are absent or zero in vars.
+func (t *Template) missing(vars any) []string {
+	if len(t.Required) == 0 {
+		return nil
+	}
+
+	v := reflect.ValueOf(vars)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return t.Required
+		}
+		v = v.Elem()
+	}
+
+	var missing []string
+	for _, name := range t.Required {
+		var f reflect.Value
+		switch v.Kind() {
+		case reflect.Map:
+			if v.Type().Key().Kind() == reflect.String {
+				f = v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+			}
+		case reflect.Struct:
+			f = v.FieldByName(name)
+		}
+		for f.IsValid() && f.Kind() == reflect.Interface && !f.IsNil() {
+			f = f.Elem()
+		}
+		if !f.IsValid() || f.IsZero() {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,77 @@
+package structured
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// extractJSON finds the JSON value in a model answer that may be wrapped in a
+// markdown code fence or surrounded by prose.
+func extractJSON(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if json.Valid([]byte(s)) {
+		return s, nil
+	}
+
+	if start := strings.Index(s, "```"); start >= 0 {
+		body := s[start+3:]
+		// Skip the info string, e.g. ```json.
+		if nl := strings.IndexByte(body, '\n'); nl >= 0 {
+			body = body[nl+1:]
+		}
+		if end := strings.Index(body, "```"); end >= 0 {
+			body = strings.TrimSpace(body[:end])
+			if json.Valid([]byte(body)) {
+				return body, nil
+			}
+		}
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '{' && s[i] != '[' {
+			continue
+		}
+		if end := matchingBracket(s, i); end > 0 && json.Valid([]byte(s[i:end+1])) {
+			return s[i : end+1], nil
+		}
+	}
+
+	return "", fmt.Errorf("response does not contain a JSON value")
+}
+
+// matchingBracket returns the index of the bracket closing the one at start,
+// ignoring brackets inside strings, or -1.
+func matchingBracket(s string, start int) int {
+	var (
+		depth    int
+		inString bool
+		escaped  bool
+	)
+	for i := start; i < len(s); i++ {
+		c := s[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
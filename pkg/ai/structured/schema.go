@@ -0,0 +1,216 @@
+package structured
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// SchemaOf returns the JSON schema of T. Struct fields are named after their
+// json tags and are required unless tagged omitempty or pointers; a
+// `description` tag is copied into the schema. Proto messages use their
+// protojson field names.
+func SchemaOf[T any]() (json.RawMessage, error) {
+	var schema map[string]any
+	if msg, ok := newMessage[T](); ok {
+		schema = messageSchema(msg.ProtoReflect().Descriptor(), map[protoreflect.FullName]bool{})
+	} else {
+		schema = typeSchema(reflect.TypeFor[T](), map[reflect.Type]bool{})
+	}
+
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal schema: %w", err)
+	}
+	return b, nil
+}
+
+type field struct {
+	name        string
+	typ         reflect.Type
+	required    bool
+	description string
+}
+
+func structFields(t reflect.Type) []field {
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, flags, _ := strings.Cut(tag, ",")
+
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				fields = append(fields, structFields(ft)...)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+
+		fields = append(fields, field{
+			name:        name,
+			typ:         sf.Type,
+			required:    !strings.Contains(flags, "omitempty") && sf.Type.Kind() != reflect.Pointer,
+			description: sf.Tag.Get("description"),
+		})
+	}
+	return fields
+}
+
+var (
+	timeType       = reflect.TypeFor[time.Time]()
+	rawMessageType = reflect.TypeFor[json.RawMessage]()
+	protoType      = reflect.TypeFor[proto.Message]()
+)
+
+func typeSchema(t reflect.Type, seen map[reflect.Type]bool) map[string]any {
+	switch t {
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case rawMessageType:
+		return map[string]any{}
+	}
+	if t.Kind() == reflect.Pointer && t.Implements(protoType) {
+		msg := reflect.New(t.Elem()).Interface().(proto.Message)
+		return messageSchema(msg.ProtoReflect().Descriptor(), map[protoreflect.FullName]bool{})
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		// The dynamic type of an interface is unknown, so any value is allowed.
+		return map[string]any{}
+	case reflect.Pointer:
+		return typeSchema(t.Elem(), seen)
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem(), seen)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": typeSchema(t.Elem(), seen)}
+	case reflect.Struct:
+		// Recursive types are left open rather than expanded forever.
+		if seen[t] {
+			return map[string]any{"type": "object"}
+		}
+		seen[t] = true
+		defer delete(seen, t)
+
+		props := map[string]any{}
+		required := []string{}
+		for _, f := range structFields(t) {
+			s := typeSchema(f.typ, seen)
+			if f.description != "" {
+				s["description"] = f.description
+			}
+			props[f.name] = s
+			if f.required {
+				required = append(required, f.name)
+			}
+		}
+		return map[string]any{
+			"type":                 "object",
+			"properties":           props,
+			"required":             required,
+			"additionalProperties": false,
+		}
+	}
+	return map[string]any{}
+}
+
+func messageSchema(md protoreflect.MessageDescriptor, seen map[protoreflect.FullName]bool) map[string]any {
+	switch md.FullName() {
+	case "google.protobuf.Timestamp":
+		return map[string]any{"type": "string", "format": "date-time"}
+	case "google.protobuf.Duration":
+		return map[string]any{"type": "string", "pattern": `^-?[0-9]+(\.[0-9]+)?s$`}
+	case "google.protobuf.Struct":
+		return map[string]any{"type": "object"}
+	case "google.protobuf.Value":
+		return map[string]any{}
+	case "google.protobuf.ListValue":
+		return map[string]any{"type": "array"}
+	}
+	if strings.HasPrefix(string(md.FullName()), "google.protobuf.") && strings.HasSuffix(string(md.Name()), "Value") {
+		return kindSchema(md.Fields().ByName("value"), seen)
+	}
+
+	if seen[md.FullName()] {
+		return map[string]any{"type": "object"}
+	}
+	seen[md.FullName()] = true
+	defer delete(seen, md.FullName())
+
+	props := map[string]any{}
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+
+		var s map[string]any
+		switch {
+		case fd.IsMap():
+			s = map[string]any{"type": "object", "additionalProperties": kindSchema(fd.MapValue(), seen)}
+		case fd.IsList():
+			s = map[string]any{"type": "array", "items": kindSchema(fd, seen)}
+		default:
+			s = kindSchema(fd, seen)
+		}
+		props[fd.JSONName()] = s
+	}
+
+	return map[string]any{
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+}
+
+func kindSchema(fd protoreflect.FieldDescriptor, seen map[protoreflect.FullName]bool) map[string]any {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return map[string]any{"type": "string"}
+	case protoreflect.BytesKind:
+		return map[string]any{"type": "string", "contentEncoding": "base64"}
+	case protoreflect.BoolKind:
+		return map[string]any{"type": "boolean"}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return map[string]any{"type": "number"}
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		names := make([]string, 0, values.Len())
+		for i := 0; i < values.Len(); i++ {
+			names = append(names, string(values.Get(i).Name()))
+		}
+		return map[string]any{"type": "string", "enum": names}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return messageSchema(fd.Message(), seen)
+	default:
+		return map[string]any{"type": "integer"}
+	}
+}
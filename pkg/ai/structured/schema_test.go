@@ -0,0 +1,136 @@
+package structured
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	filev1 "github.com/bexprt/bexgen-client/pb/file/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func schemaMap[T any](t *testing.T) map[string]any {
+	t.Helper()
+	b, err := SchemaOf[T]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s map[string]any
+	if err := json.Unmarshal(b, &s); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func property(t *testing.T, s map[string]any, name string) map[string]any {
+	t.Helper()
+	props, _ := s["properties"].(map[string]any)
+	p, ok := props[name].(map[string]any)
+	if !ok {
+		t.Fatalf("schema has no property %q: %v", name, s)
+	}
+	return p
+}
+
+func required(s map[string]any) []string {
+	var names []string
+	for _, n := range s["required"].([]any) {
+		names = append(names, n.(string))
+	}
+	return names
+}
+
+type invoice struct {
+	Number string   `json:"number" description:"Invoice number"`
+	Total  float64  `json:"total"`
+	Note   string   `json:"note,omitempty"`
+	Due    *string  `json:"due"`
+	Lines  []line   `json:"lines"`
+	Tags   []string `json:"-"`
+	hidden string
+}
+
+type line struct {
+	Quantity int `json:"quantity"`
+}
+
+func TestSchemaOfStruct(t *testing.T) {
+	s := schemaMap[invoice](t)
+
+	if s["type"] != "object" || s["additionalProperties"] != false {
+		t.Fatalf("unexpected object schema: %v", s)
+	}
+	if got, want := required(s), []string{"number", "total", "lines"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("required = %v, want %v", got, want)
+	}
+	if p := property(t, s, "number"); p["type"] != "string" || p["description"] != "Invoice number" {
+		t.Fatalf("number = %v", p)
+	}
+	if p := property(t, s, "due"); p["type"] != "string" {
+		t.Fatalf("pointer field due = %v, want the element schema", p)
+	}
+	items := property(t, s, "lines")["items"].(map[string]any)
+	if p := property(t, items, "quantity"); p["type"] != "integer" {
+		t.Fatalf("lines.items.quantity = %v", p)
+	}
+
+	props := s["properties"].(map[string]any)
+	for _, name := range []string{"Tags", "hidden"} {
+		if _, ok := props[name]; ok {
+			t.Fatalf("schema includes skipped field %s", name)
+		}
+	}
+}
+
+func TestSchemaOfPointer(t *testing.T) {
+	if got, want := schemaMap[*invoice](t), schemaMap[invoice](t); !reflect.DeepEqual(got, want) {
+		t.Fatalf("SchemaOf[*invoice] = %v, want %v", got, want)
+	}
+}
+
+type node struct {
+	Name     string `json:"name"`
+	Children []node `json:"children,omitempty"`
+}
+
+func TestSchemaOfRecursive(t *testing.T) {
+	s := schemaMap[node](t)
+
+	items := property(t, s, "children")["items"].(map[string]any)
+	if !reflect.DeepEqual(items, map[string]any{"type": "object"}) {
+		t.Fatalf("recursive items = %v, want an open object", items)
+	}
+}
+
+type envelope struct {
+	File    *filev1.FileUpload `json:"file"`
+	Extra   proto.Message      `json:"extra,omitempty"`
+	Payload any                `json:"payload"`
+}
+
+func TestSchemaOfProtoAndInterfaceFields(t *testing.T) {
+	s := schemaMap[envelope](t)
+
+	file := property(t, s, "file")
+	if p := property(t, file, "filePath"); p["type"] != "string" {
+		t.Fatalf("file.filePath = %v, want the protojson name", p)
+	}
+	if p := property(t, file, "sizeBytes"); p["type"] != "integer" {
+		t.Fatalf("file.sizeBytes = %v", p)
+	}
+
+	for _, name := range []string{"extra", "payload"} {
+		if p := property(t, s, name); len(p) != 0 {
+			t.Fatalf("interface field %s = %v, want an open schema", name, p)
+		}
+	}
+}
+
+func TestSchemaOfProtoMessage(t *testing.T) {
+	s := schemaMap[*filev1.FileUpload](t)
+
+	if _, ok := s["required"]; ok {
+		t.Fatalf("proto schema has required fields: %v", s)
+	}
+	property(t, s, "uploadedAt")
+}
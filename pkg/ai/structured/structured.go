@@ -0,0 +1,222 @@
+// Package structured asks a model for JSON shaped like a Go type and decodes
+// the answer, re-prompting the model with the errors when it doesn't comply.
+package structured
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/bexprt/bexgen-client/pkg/ai/types"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const defaultMaxRetries = 2
+
+type Options struct {
+	// Schema replaces the JSON schema generated from the target type.
+	Schema json.RawMessage
+	// System is prepended to the output instructions in the system prompt.
+	System string
+	// MaxRetries is the number of corrective prompts sent after an invalid
+	// answer. Defaults to 2; a negative value disables retries.
+	MaxRetries int
+	Inference  types.InferenceConfig
+}
+
+// Validator is implemented by target types with checks beyond the schema.
+type Validator interface {
+	Validate() error
+}
+
+// Error is returned when the model didn't produce a valid answer within the
+// allowed attempts.
+type Error struct {
+	Attempts int
+	// Response is the last raw model answer.
+	Response string
+	Err      error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("structured output invalid after %d attempts: %v", e.Attempts, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Generate prompts model for a JSON value of type T and decodes it. T may be
+// a struct (decoded with encoding/json) or a pointer to a proto message
+// (decoded with protojson).
+func Generate[T any](ctx context.Context, model types.Model, prompt string, opts *Options) (T, error) {
+	var zero T
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	schema := opts.Schema
+	if len(schema) == 0 {
+		s, err := SchemaOf[T]()
+		if err != nil {
+			return zero, err
+		}
+		schema = s
+	}
+
+	maxRetries := opts.MaxRetries
+	switch {
+	case maxRetries == 0:
+		maxRetries = defaultMaxRetries
+	case maxRetries < 0:
+		maxRetries = 0
+	}
+
+	req := &types.Request{
+		System:    systemPrompt(opts.System, schema),
+		Messages:  []types.Message{types.UserMessage(prompt)},
+		Inference: opts.Inference,
+	}
+
+	var (
+		answer string
+		err    error
+	)
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		resp, cerr := model.Converse(ctx, req)
+		if cerr != nil {
+			return zero, fmt.Errorf("failed to generate structured output: %w", cerr)
+		}
+		answer = resp.Text()
+
+		var out T
+		out, err = decode[T](answer)
+		if err == nil {
+			return out, nil
+		}
+
+		req.Messages = append(req.Messages,
+			types.Message{Role: types.RoleAssistant, Content: []types.ContentBlock{{Text: answer}}},
+			types.UserMessage(correction(err)),
+		)
+	}
+
+	return zero, &Error{Attempts: maxRetries + 1, Response: answer, Err: err}
+}
+
+func systemPrompt(system string, schema json.RawMessage) string {
+	var b strings.Builder
+	if system != "" {
+		b.WriteString(system)
+		b.WriteString("\n\n")
+	}
+	b.WriteString("Respond with a single JSON value that conforms to this JSON schema:\n")
+	b.Write(schema)
+	b.WriteString("\nDo not wrap the JSON in markdown and do not add any other text.")
+	return b.String()
+}
+
+func correction(err error) string {
+	return "Your previous answer was invalid:\n" + err.Error() +
+		"\nReply again with only the corrected JSON."
+}
+
+func decode[T any](answer string) (T, error) {
+	var out T
+
+	raw, err := extractJSON(answer)
+	if err != nil {
+		return out, err
+	}
+
+	if msg, ok := newMessage[T](); ok {
+		if err := protojson.Unmarshal([]byte(raw), msg); err != nil {
+			return out, fmt.Errorf("json does not match the schema: %w", err)
+		}
+		out = msg.(T)
+	} else {
+		if err := checkRequired(reflect.TypeOf(out), json.RawMessage(raw), ""); err != nil {
+			return out, err
+		}
+		dec := json.NewDecoder(strings.NewReader(raw))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&out); err != nil {
+			return out, fmt.Errorf("json does not match the schema: %w", err)
+		}
+	}
+
+	if v, ok := any(out).(Validator); ok {
+		if err := v.Validate(); err != nil {
+			return out, err
+		}
+	} else if v, ok := any(&out).(Validator); ok {
+		if err := v.Validate(); err != nil {
+			return out, err
+		}
+	}
+
+	return out, nil
+}
+
+// newMessage allocates a T when T is a pointer to a proto message.
+func newMessage[T any]() (proto.Message, bool) {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Pointer || !t.Implements(reflect.TypeFor[proto.Message]()) {
+		return nil, false
+	}
+	return reflect.New(t.Elem()).Interface().(proto.Message), true
+}
+
+// checkRequired reports missing required fields, which encoding/json would
+// silently leave zero.
+func checkRequired(t reflect.Type, raw json.RawMessage, path string) error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &obj); err != nil || obj == nil {
+			return nil
+		}
+		var errs []error
+		for _, f := range structFields(t) {
+			value, ok := obj[f.name]
+			if !ok {
+				if f.required {
+					errs = append(errs, fmt.Errorf("missing required field %q", joinPath(path, f.name)))
+				}
+				continue
+			}
+			if err := checkRequired(f.typ, value, joinPath(path, f.name)); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil
+		}
+		var errs []error
+		for i, item := range items {
+			if err := checkRequired(t.Elem(), item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
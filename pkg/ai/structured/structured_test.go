@@ -0,0 +1,135 @@
+package structured
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bexprt/bexgen-client/pkg/ai/types"
+)
+
+// scriptedModel answers Converse with its answers in order and keeps the
+// requests it was sent.
+type scriptedModel struct {
+	answers  []string
+	requests []types.Request
+}
+
+func (m *scriptedModel) Invoke(ctx context.Context, prompt string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *scriptedModel) Stream(ctx context.Context, prompt string) (<-chan types.StreamEvent, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *scriptedModel) Converse(ctx context.Context, req *types.Request) (*types.Response, error) {
+	r := *req
+	r.Messages = append([]types.Message(nil), req.Messages...)
+	m.requests = append(m.requests, r)
+
+	if len(m.answers) == 0 {
+		return nil, fmt.Errorf("no answer left")
+	}
+	answer := m.answers[0]
+	m.answers = m.answers[1:]
+	return &types.Response{Message: types.Message{
+		Role:    types.RoleAssistant,
+		Content: []types.ContentBlock{{Text: answer}},
+	}}, nil
+}
+
+func (m *scriptedModel) ConverseStream(ctx context.Context, req *types.Request) (<-chan types.StreamEvent, error) {
+	return nil, errors.New("not implemented")
+}
+
+type person struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func (p *person) Validate() error {
+	if p.Age < 0 {
+		return fmt.Errorf("age must be positive")
+	}
+	return nil
+}
+
+func TestGenerateRepromptsWithErrors(t *testing.T) {
+	model := &scriptedModel{answers: []string{
+		`{"name": "Ada"}`,
+		`{"name": "Ada", "age": -1}`,
+		"Here it is:\n```json\n{\"name\": \"Ada\", \"age\": 36}\n```",
+	}}
+
+	got, err := Generate[person](context.Background(), model, "who?", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != (person{Name: "Ada", Age: 36}) {
+		t.Fatalf("Generate = %+v", got)
+	}
+
+	if len(model.requests) != 3 {
+		t.Fatalf("model called %d times, want 3", len(model.requests))
+	}
+	second := model.requests[1].Messages
+	if len(second) != 3 || second[1].Role != types.RoleAssistant {
+		t.Fatalf("retry does not replay the invalid answer: %+v", second)
+	}
+	if text := second[2].Content[0].Text; !strings.Contains(text, `missing required field "age"`) {
+		t.Fatalf("correction does not report the missing field: %q", text)
+	}
+	if text := model.requests[2].Messages[4].Content[0].Text; !strings.Contains(text, "age must be positive") {
+		t.Fatalf("correction does not report the Validate error: %q", text)
+	}
+}
+
+func TestGenerateGivesUp(t *testing.T) {
+	model := &scriptedModel{answers: []string{"no", "still no"}}
+
+	_, err := Generate[person](context.Background(), model, "who?", &Options{MaxRetries: 1})
+	var serr *Error
+	if !errors.As(err, &serr) {
+		t.Fatalf("Generate error = %v, want *Error", err)
+	}
+	if serr.Attempts != 2 || serr.Response != "still no" {
+		t.Fatalf("Error = %+v", serr)
+	}
+}
+
+func TestGenerateRejectsUnknownFields(t *testing.T) {
+	model := &scriptedModel{answers: []string{`{"name": "Ada", "age": 36, "email": "a@b"}`}}
+
+	_, err := Generate[person](context.Background(), model, "who?", &Options{MaxRetries: -1})
+	if err == nil || !strings.Contains(err.Error(), "email") {
+		t.Fatalf("Generate error = %v, want the unknown field", err)
+	}
+}
+
+func TestExtractJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", ` {"a": 1} `, `{"a": 1}`},
+		{"fence", "```json\n{\"a\": 1}\n```", `{"a": 1}`},
+		{"prose", `The answer is {"a": "}"} as requested.`, `{"a": "}"}`},
+		{"array", `Result: [1, 2]`, `[1, 2]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extractJSON(tt.in)
+			if err != nil || got != tt.want {
+				t.Fatalf("extractJSON = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+
+	if _, err := extractJSON("no json here"); err == nil {
+		t.Fatal("expected an error without JSON")
+	}
+}
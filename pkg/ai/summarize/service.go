@@ -0,0 +1,91 @@
+package summarize
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bexprt/bexgen-client/pkg/ai/usage"
+	searchtypes "github.com/bexprt/bexgen-client/pkg/database/search/types"
+	db "github.com/bexprt/bexgen-client/pkg/database/sql"
+	messagingtypes "github.com/bexprt/bexgen-client/pkg/messaging/types"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Service summarizes indexed documents and stores the summary in the search
+// index and the documents table, then publishes it on the
+// topics.DocumentSummarized channel.
+type Service struct {
+	summarizer *Summarizer
+	index      searchtypes.Index
+	queries    db.Querier
+	events     chan<- *messagingtypes.Message[*structpb.Struct]
+}
+
+// NewService builds a service. events is the channel of a publisher opened
+// for topics.DocumentSummarized; nil disables the event.
+func NewService(
+	summarizer *Summarizer,
+	index searchtypes.Index,
+	queries db.Querier,
+	events chan<- *messagingtypes.Message[*structpb.Struct],
+) *Service {
+	return &Service{
+		summarizer: summarizer,
+		index:      index,
+		queries:    queries,
+		events:     events,
+	}
+}
+
+// Process summarizes the OCR text of an indexed document. The model calls
+// are attributed to the document in the usage records.
+func (s *Service) Process(ctx context.Context, documentID string) (*Result, error) {
+	id, err := uuid.Parse(documentID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid document id %q: %w", documentID, err)
+	}
+
+	doc, err := s.index.GetByID(ctx, documentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document %s: %w", documentID, err)
+	}
+
+	res, err := s.summarizer.Summarize(usage.WithDocumentID(ctx, documentID), doc.Text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize document %s: %w", documentID, err)
+	}
+
+	doc.Summary = res.Summary
+	if err := s.index.Update(ctx, doc); err != nil {
+		return nil, fmt.Errorf("failed to index summary: %w", err)
+	}
+
+	err = s.queries.UpdateDocumentSummary(ctx, db.UpdateDocumentSummaryParams{
+		ID:      id,
+		Summary: res.Summary,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store summary: %w", err)
+	}
+
+	if s.events != nil {
+		event, err := structpb.NewStruct(map[string]any{
+			"id":            documentID,
+			"summary":       res.Summary,
+			"chunks":        res.Chunks,
+			"promptVersion": res.PromptVersion,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build summary event: %w", err)
+		}
+
+		select {
+		case s.events <- &messagingtypes.Message[*structpb.Struct]{Key: &documentID, Value: event}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,321 @@
+// Package summarize summarizes documents longer than a model's context with
+// a map-reduce over chunks: chunks are summarized in parallel, then their
+// summaries are combined until a single summary fits the length budget.
+package summarize
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/bexprt/bexgen-client/pkg/ai/chunker"
+	"github.com/bexprt/bexgen-client/pkg/ai/prompt"
+	"github.com/bexprt/bexgen-client/pkg/ai/types"
+)
+
+const (
+	defaultChunkTokens  = 2000
+	defaultReduceTokens = 6000
+	defaultConcurrency  = 4
+	defaultMaxWords     = 250
+	defaultChunkWords   = 150
+)
+
+type Options struct {
+	// ChunkTokens is the size of the chunks summarized in the map step.
+	// Defaults to 2000.
+	ChunkTokens int
+	// ReduceTokens bounds the summaries combined by one reduce call; more
+	// summaries are reduced over several rounds. Defaults to 6000.
+	ReduceTokens int
+	// Concurrency is the number of model calls in flight. Defaults to 4.
+	Concurrency int
+	// MaxWords is the length budget of the final summary. Defaults to 250.
+	MaxWords int
+	// ChunkWords is the length budget of intermediate summaries. Defaults
+	// to 150.
+	ChunkWords int
+	// Tokenizer defaults to chunker.HeuristicTokenizer.
+	Tokenizer chunker.Tokenizer
+	// Prompts defaults to prompt.Default().
+	Prompts *prompt.Registry
+	// PromptVersion pins the version of the summarize-chunk and
+	// summarize-reduce prompts. Empty selects the latest.
+	PromptVersion string
+	Inference     types.InferenceConfig
+}
+
+type Result struct {
+	Summary string
+	// Chunks is the number of chunks of the input, Rounds the number of
+	// reduce rounds needed to combine their summaries.
+	Chunks int
+	Rounds int
+	// PromptVersion is the version of the prompt that produced the final
+	// summary.
+	PromptVersion string
+	Usage         types.Usage
+}
+
+type Summarizer struct {
+	model types.Model
+	opts  Options
+}
+
+func New(model types.Model, opts Options) (*Summarizer, error) {
+	if model == nil {
+		return nil, fmt.Errorf("summarizer requires a model")
+	}
+	if opts.ChunkTokens == 0 {
+		opts.ChunkTokens = defaultChunkTokens
+	}
+	if opts.ReduceTokens == 0 {
+		opts.ReduceTokens = defaultReduceTokens
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultConcurrency
+	}
+	if opts.MaxWords == 0 {
+		opts.MaxWords = defaultMaxWords
+	}
+	if opts.ChunkWords == 0 {
+		opts.ChunkWords = defaultChunkWords
+	}
+	if opts.Tokenizer == nil {
+		opts.Tokenizer = chunker.HeuristicTokenizer{}
+	}
+	if opts.Prompts == nil {
+		prompts, err := prompt.Default()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load default prompts: %w", err)
+		}
+		opts.Prompts = prompts
+	}
+
+	for _, name := range []string{prompt.SummarizeChunk, prompt.SummarizeReduce} {
+		if _, err := opts.Prompts.Get(name, opts.PromptVersion); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Summarizer{model: model, opts: opts}, nil
+}
+
+// Summarize returns a summary of text of at most MaxWords words.
+func (s *Summarizer) Summarize(ctx context.Context, text string) (*Result, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("nothing to summarize")
+	}
+
+	chunks, err := chunker.Split(text, &chunker.Options{
+		MaxTokens: s.opts.ChunkTokens,
+		Tokenizer: s.opts.Tokenizer,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to chunk text: %w", err)
+	}
+
+	res := &Result{Chunks: len(chunks)}
+	acc := &usageAcc{}
+
+	if len(chunks) == 1 {
+		summary, err := s.summarizeChunk(ctx, acc, chunks[0].Text, 0, 0, s.opts.MaxWords)
+		if err != nil {
+			return nil, err
+		}
+		res.Summary = truncateWords(summary, s.opts.MaxWords)
+		res.PromptVersion = s.version(prompt.SummarizeChunk)
+		res.Usage = acc.total()
+		return res, nil
+	}
+
+	summaries := make([]string, len(chunks))
+	err = s.parallel(ctx, len(chunks), func(ctx context.Context, i int) error {
+		summary, err := s.summarizeChunk(ctx, acc, chunks[i].Text, i+1, len(chunks), s.opts.ChunkWords)
+		summaries[i] = summary
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for len(summaries) > 1 {
+		res.Rounds++
+		groups := s.group(summaries)
+
+		maxWords := s.opts.ChunkWords
+		if len(groups) == 1 {
+			maxWords = s.opts.MaxWords
+		}
+
+		reduced := make([]string, len(groups))
+		err := s.parallel(ctx, len(groups), func(ctx context.Context, i int) error {
+			summary, err := s.reduce(ctx, acc, groups[i], maxWords)
+			reduced[i] = summary
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		summaries = reduced
+	}
+
+	res.Summary = truncateWords(summaries[0], s.opts.MaxWords)
+	res.PromptVersion = s.version(prompt.SummarizeReduce)
+	res.Usage = acc.total()
+	return res, nil
+}
+
+// version resolves the configured version of a prompt, which New checked.
+func (s *Summarizer) version(name string) string {
+	t, err := s.opts.Prompts.Get(name, s.opts.PromptVersion)
+	if err != nil {
+		return s.opts.PromptVersion
+	}
+	return t.Version
+}
+
+func (s *Summarizer) summarizeChunk(ctx context.Context, acc *usageAcc, text string, part, parts, maxWords int) (string, error) {
+	p, err := s.opts.Prompts.Render(prompt.SummarizeChunk, s.opts.PromptVersion, map[string]any{
+		"Text":     text,
+		"Part":     part,
+		"Parts":    parts,
+		"MaxWords": maxWords,
+	})
+	if err != nil {
+		return "", err
+	}
+	return s.complete(ctx, acc, p)
+}
+
+func (s *Summarizer) reduce(ctx context.Context, acc *usageAcc, summaries []string, maxWords int) (string, error) {
+	p, err := s.opts.Prompts.Render(prompt.SummarizeReduce, s.opts.PromptVersion, map[string]any{
+		"Summaries": summaries,
+		"MaxWords":  maxWords,
+	})
+	if err != nil {
+		return "", err
+	}
+	return s.complete(ctx, acc, p)
+}
+
+func (s *Summarizer) complete(ctx context.Context, acc *usageAcc, p prompt.Rendered) (string, error) {
+	resp, err := s.model.Converse(p.Context(ctx), &types.Request{
+		Messages:  []types.Message{types.UserMessage(p.Text)},
+		Inference: s.opts.Inference,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to summarize: %w", err)
+	}
+	acc.add(resp.Usage)
+
+	summary := strings.TrimSpace(resp.Text())
+	if summary == "" {
+		return "", fmt.Errorf("model returned an empty summary")
+	}
+	return summary, nil
+}
+
+// group splits consecutive summaries into groups of at most ReduceTokens
+// tokens. Every group holds at least two summaries so that each round makes
+// progress.
+func (s *Summarizer) group(summaries []string) [][]string {
+	var (
+		groups [][]string
+		cur    []string
+		tokens int
+	)
+	for _, summary := range summaries {
+		n := s.opts.Tokenizer.Count(summary)
+		if len(cur) >= 2 && tokens+n > s.opts.ReduceTokens {
+			groups = append(groups, cur)
+			cur, tokens = nil, 0
+		}
+		cur = append(cur, summary)
+		tokens += n
+	}
+
+	// A trailing single summary joins the previous group.
+	if len(cur) == 1 && len(groups) > 0 {
+		last := len(groups) - 1
+		groups[last] = append(groups[last], cur[0])
+	} else {
+		groups = append(groups, cur)
+	}
+	return groups
+}
+
+// parallel runs fn for 0..n-1 with at most Concurrency calls in flight and
+// returns the first error, cancelling the remaining calls.
+func (s *Summarizer) parallel(ctx context.Context, n int, fn func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		sem      = make(chan struct{}, s.opts.Concurrency)
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var err error
+			select {
+			case sem <- struct{}{}:
+				err = fn(ctx, i)
+				<-sem
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+
+			if err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
+type usageAcc struct {
+	mu    sync.Mutex
+	usage types.Usage
+}
+
+func (a *usageAcc) add(u types.Usage) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.usage.InputTokens += u.InputTokens
+	a.usage.OutputTokens += u.OutputTokens
+}
+
+func (a *usageAcc) total() types.Usage {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.usage
+}
+
+// truncateWords enforces the length budget when the model overshoots it,
+// cutting after the last complete sentence that fits, or at a word boundary
+// when that would drop more than half of the budget.
+func truncateWords(text string, maxWords int) string {
+	words := strings.Fields(text)
+	if len(words) <= maxWords {
+		return text
+	}
+
+	cut := strings.Join(words[:maxWords], " ")
+	if i := strings.LastIndexAny(cut, ".!?"); i > len(cut)/2 {
+		return cut[:i+1]
+	}
+	return cut + "…"
+}
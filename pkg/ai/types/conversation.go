@@ -0,0 +1,125 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleAssistant Role = "assistant"
+)
+
+// ContentBlock is one part of a message. Exactly one field is set.
+type ContentBlock struct {
+	Text       string
+	Image      *Media
+	Document   *Media
+	ToolUse    *ToolUse
+	ToolResult *ToolResult
+}
+
+// ToolUse is a tool call requested by the model. Input is a JSON object
+// matching the tool's input schema.
+type ToolUse struct {
+	ID    string
+	Name  string
+	Input json.RawMessage
+}
+
+// ToolResult answers a ToolUse in the following user message. Either Text or
+// JSON is sent back to the model.
+type ToolResult struct {
+	ToolUseID string
+	Text      string
+	JSON      json.RawMessage
+	IsError   bool
+}
+
+type Message struct {
+	Role    Role
+	Content []ContentBlock
+}
+
+// Tool describes a function the model may call. InputSchema is a JSON
+// schema object.
+type Tool struct {
+	Name        string
+	Description string
+	InputSchema json.RawMessage
+}
+
+type ToolChoiceType string
+
+const (
+	// ToolChoiceAuto lets the model decide whether to call a tool.
+	ToolChoiceAuto ToolChoiceType = "auto"
+	// ToolChoiceAny forces the model to call one of the tools.
+	ToolChoiceAny ToolChoiceType = "any"
+	// ToolChoiceTool forces the model to call the tool named in ToolChoice.
+	ToolChoiceTool ToolChoiceType = "tool"
+)
+
+type ToolChoice struct {
+	Type ToolChoiceType
+	Name string
+}
+
+// InferenceConfig overrides the driver defaults for a single call. Zero
+// values and nil pointers keep the configured defaults.
+type InferenceConfig struct {
+	MaxTokens     int
+	Temperature   *float32
+	TopP          *float32
+	StopSequences []string
+}
+
+type Request struct {
+	System     string
+	Messages   []Message
+	Inference  InferenceConfig
+	Tools      []Tool
+	ToolChoice *ToolChoice
+}
+
+// Stop reasons reported by every driver.
+const (
+	StopEndTurn         = "end_turn"
+	StopToolUse         = "tool_use"
+	StopMaxTokens       = "max_tokens"
+	StopSequence        = "stop_sequence"
+	StopContentFiltered = "content_filtered"
+)
+
+type Response struct {
+	Message    Message
+	StopReason string
+	Usage      Usage
+}
+
+// UserMessage builds a single text message from the user.
+func UserMessage(text string) Message {
+	return Message{Role: RoleUser, Content: []ContentBlock{{Text: text}}}
+}
+
+// Text concatenates the text blocks of the response message.
+func (r *Response) Text() string {
+	var b strings.Builder
+	for _, c := range r.Message.Content {
+		b.WriteString(c.Text)
+	}
+	return b.String()
+}
+
+// ToolUses returns the tool calls requested by the model.
+func (r *Response) ToolUses() []ToolUse {
+	var uses []ToolUse
+	for _, c := range r.Message.Content {
+		if c.ToolUse != nil {
+			uses = append(uses, *c.ToolUse)
+		}
+	}
+	return uses
+}
@@ -0,0 +1,89 @@
+package types
+
+import (
+	"path"
+	"strings"
+)
+
+// MediaFormat is the file format of an image or document block.
+type MediaFormat string
+
+const (
+	FormatPNG  MediaFormat = "png"
+	FormatJPEG MediaFormat = "jpeg"
+	FormatGIF  MediaFormat = "gif"
+	FormatWEBP MediaFormat = "webp"
+
+	FormatPDF  MediaFormat = "pdf"
+	FormatCSV  MediaFormat = "csv"
+	FormatDOC  MediaFormat = "doc"
+	FormatDOCX MediaFormat = "docx"
+	FormatXLS  MediaFormat = "xls"
+	FormatXLSX MediaFormat = "xlsx"
+	FormatHTML MediaFormat = "html"
+	FormatTXT  MediaFormat = "txt"
+	FormatMD   MediaFormat = "md"
+)
+
+// IsImage reports whether f is an image format.
+func (f MediaFormat) IsImage() bool {
+	switch f {
+	case FormatPNG, FormatJPEG, FormatGIF, FormatWEBP:
+		return true
+	}
+	return false
+}
+
+// FormatOf guesses the format of a file from its extension, or returns ""
+// for unknown extensions.
+func FormatOf(name string) MediaFormat {
+	ext := strings.ToLower(strings.TrimPrefix(path.Ext(name), "."))
+	switch ext {
+	case "jpg":
+		return FormatJPEG
+	case "htm":
+		return FormatHTML
+	case "markdown":
+		return FormatMD
+	}
+	f := MediaFormat(ext)
+	switch f {
+	case FormatPNG, FormatJPEG, FormatGIF, FormatWEBP,
+		FormatPDF, FormatCSV, FormatDOC, FormatDOCX, FormatXLS, FormatXLSX,
+		FormatHTML, FormatTXT, FormatMD:
+		return f
+	}
+	return ""
+}
+
+// Media is an image or document sent to the model. Data holds the file
+// content; alternatively Path names an object in storage, which must be
+// loaded with media.Resolve before the request reaches a driver.
+type Media struct {
+	Format MediaFormat
+	// Name identifies a document to the model. Defaults to the base name
+	// of Path.
+	Name string
+	Data []byte
+	Path string
+}
+
+// ImageBlock builds an image content block from file content.
+func ImageBlock(format MediaFormat, data []byte) ContentBlock {
+	return ContentBlock{Image: &Media{Format: format, Data: data}}
+}
+
+// DocumentBlock builds a document content block from file content.
+func DocumentBlock(format MediaFormat, name string, data []byte) ContentBlock {
+	return ContentBlock{Document: &Media{Format: format, Name: name, Data: data}}
+}
+
+// StoredBlock builds an image or document content block for an object in
+// storage, with the format taken from the path extension.
+func StoredBlock(path string) ContentBlock {
+	m := &Media{Format: FormatOf(path), Path: path}
+	if m.Format.IsImage() {
+		return ContentBlock{Image: m}
+	}
+	return ContentBlock{Document: m}
+}
@@ -2,14 +2,58 @@ package types
 
 import (
 	"context"
+	"fmt"
+	"slices"
 )
 
 type Model interface {
 	Invoke(ctx context.Context, propmt string) (string, error)
+
+	// Stream generates a completion incrementally. The channel is closed when
+	// the model stops, the stream fails or ctx is cancelled.
+	Stream(ctx context.Context, prompt string) (<-chan StreamEvent, error)
+
+	// Converse runs one turn of a conversation, with optional system prompt
+	// and tools.
+	Converse(ctx context.Context, req *Request) (*Response, error)
+
+	// ConverseStream is the streaming variant of Converse.
+	ConverseStream(ctx context.Context, req *Request) (<-chan StreamEvent, error)
+}
+
+// Usage is the token accounting of a single model call.
+type Usage struct {
+	InputTokens  int
+	OutputTokens int
+}
+
+// StreamEvent is one element of a streamed completion. Text arrives in Delta
+// and complete tool calls in ToolUse; the last event carries StopReason and
+// Usage, or Err if the stream failed.
+type StreamEvent struct {
+	Delta      string
+	ToolUse    *ToolUse
+	StopReason string
+	Usage      *Usage
+	Err        error
+}
+
+type RerankOptions struct {
+	// TopN limits the number of results; 0 returns every document.
+	TopN int
+}
+
+// RerankResult is a document position in the reranked input with its
+// relevance to the query.
+type RerankResult struct {
+	Index int
+	Score float32
 }
 
 type Rerank interface {
-	Rerank(ctx context.Context, texts []string) ([]string, error)
+	// Rerank scores documents against query and returns them ordered by
+	// descending relevance.
+	Rerank(ctx context.Context, query string, documents []string, opts *RerankOptions) ([]RerankResult, error)
 }
 
 type EmbeddingInputType string
@@ -21,8 +65,193 @@ const (
 	InputClustering     EmbeddingInputType = "clustering"
 )
 
+// EmbeddingType is the numeric representation of returned embeddings.
+type EmbeddingType string
+
+const (
+	EmbeddingFloat EmbeddingType = "float"
+	EmbeddingInt8  EmbeddingType = "int8"
+	EmbeddingUint8 EmbeddingType = "uint8"
+	// EmbeddingBinary packs 8 dimensions per signed byte.
+	EmbeddingBinary EmbeddingType = "binary"
+	// EmbeddingUBinary packs 8 dimensions per unsigned byte.
+	EmbeddingUBinary EmbeddingType = "ubinary"
+)
+
+// Embeddings holds vectors in a single representation; only the field
+// matching Type is set. Binary vectors have Dimension()/8 elements.
+type Embeddings struct {
+	Type    EmbeddingType
+	Float   [][]float32
+	Int8    [][]int8
+	Uint8   [][]uint8
+	Binary  [][]int8
+	UBinary [][]uint8
+}
+
+// NewEmbeddings allocates n empty vectors of type t.
+func NewEmbeddings(t EmbeddingType, n int) (*Embeddings, error) {
+	e := &Embeddings{Type: t}
+	switch t {
+	case EmbeddingFloat:
+		e.Float = make([][]float32, n)
+	case EmbeddingInt8:
+		e.Int8 = make([][]int8, n)
+	case EmbeddingUint8:
+		e.Uint8 = make([][]uint8, n)
+	case EmbeddingBinary:
+		e.Binary = make([][]int8, n)
+	case EmbeddingUBinary:
+		e.UBinary = make([][]uint8, n)
+	default:
+		return nil, fmt.Errorf("unsupported embedding type %q", t)
+	}
+	return e, nil
+}
+
+// Len returns the number of vectors.
+func (e *Embeddings) Len() int {
+	switch e.Type {
+	case EmbeddingFloat:
+		return len(e.Float)
+	case EmbeddingInt8:
+		return len(e.Int8)
+	case EmbeddingUint8:
+		return len(e.Uint8)
+	case EmbeddingBinary:
+		return len(e.Binary)
+	case EmbeddingUBinary:
+		return len(e.UBinary)
+	}
+	return 0
+}
+
+// CopyFrom copies the vectors of src to position offset. Both must have the
+// same Type.
+func (e *Embeddings) CopyFrom(offset int, src *Embeddings) {
+	switch e.Type {
+	case EmbeddingFloat:
+		copy(e.Float[offset:], src.Float)
+	case EmbeddingInt8:
+		copy(e.Int8[offset:], src.Int8)
+	case EmbeddingUint8:
+		copy(e.Uint8[offset:], src.Uint8)
+	case EmbeddingBinary:
+		copy(e.Binary[offset:], src.Binary)
+	case EmbeddingUBinary:
+		copy(e.UBinary[offset:], src.UBinary)
+	}
+}
+
+// Vectors returns the vectors as float32 values, the form search indexes
+// accept. Byte and bit index fields take values in -128..127, so int8 and
+// binary embeddings are converted as is, ubinary bytes are reinterpreted as
+// int8 with the same bits, which keeps Hamming distances, and uint8 values
+// are shifted by -128, which keeps l2 distances but not cosine similarity.
+func (e *Embeddings) Vectors() [][]float32 {
+	if e.Type == EmbeddingFloat {
+		return e.Float
+	}
+
+	out := make([][]float32, e.Len())
+	for i := range out {
+		switch e.Type {
+		case EmbeddingInt8:
+			out[i] = toFloat32(e.Int8[i])
+		case EmbeddingUint8:
+			out[i] = toFloat32(shift(e.Uint8[i]))
+		case EmbeddingBinary:
+			out[i] = toFloat32(e.Binary[i])
+		case EmbeddingUBinary:
+			out[i] = toFloat32(reinterpret(e.UBinary[i]))
+		}
+	}
+	return out
+}
+
+// shift maps 0..255 to -128..127.
+func shift(v []uint8) []int8 {
+	if v == nil {
+		return nil
+	}
+	out := make([]int8, len(v))
+	for i, x := range v {
+		out[i] = int8(int(x) - 128)
+	}
+	return out
+}
+
+// reinterpret keeps the bits of packed binary vectors.
+func reinterpret(v []uint8) []int8 {
+	if v == nil {
+		return nil
+	}
+	out := make([]int8, len(v))
+	for i, x := range v {
+		out[i] = int8(x)
+	}
+	return out
+}
+
+func toFloat32(v []int8) []float32 {
+	if v == nil {
+		return nil
+	}
+	out := make([]float32, len(v))
+	for i, x := range v {
+		out[i] = float32(x)
+	}
+	return out
+}
+
 type Embedder interface {
+	// Embed returns one vector per text, in input order. When only some of
+	// the texts fail the error is a *BatchError and the failed positions are
+	// nil.
 	Embed(ctx context.Context, texts []string, embedType EmbeddingInputType) ([][]float32, error)
 
+	// EmbedAs is like Embed but returns the requested representation.
+	EmbedAs(ctx context.Context, texts []string, embedType EmbeddingInputType, as EmbeddingType) (*Embeddings, error)
+
 	Dimension() int
 }
+
+// BatchFailure is a group of inputs that failed together.
+type BatchFailure struct {
+	Indices []int
+	Err     error
+}
+
+// BatchError is returned by batched calls when some of the inputs failed.
+// Results of the other inputs are still returned at their positions.
+type BatchError struct {
+	Total    int
+	Failures []BatchFailure
+}
+
+func (e *BatchError) Error() string {
+	failed := e.FailedIndices()
+	msg := fmt.Sprintf("%d of %d inputs failed", len(failed), e.Total)
+	if len(e.Failures) > 0 {
+		msg += ": " + e.Failures[0].Err.Error()
+	}
+	return msg
+}
+
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		errs = append(errs, f.Err)
+	}
+	return errs
+}
+
+// FailedIndices returns the sorted positions of the failed inputs.
+func (e *BatchError) FailedIndices() []int {
+	var indices []int
+	for _, f := range e.Failures {
+		indices = append(indices, f.Indices...)
+	}
+	slices.Sort(indices)
+	return indices
+}
@@ -0,0 +1,20 @@
+package usage
+
+// Price is the on-demand price of a model in USD per 1000 tokens.
+type Price struct {
+	InputPer1K  float64 `yaml:"inputPer1k"`
+	OutputPer1K float64 `yaml:"outputPer1k"`
+}
+
+// Prices maps model IDs to their price.
+type Prices map[string]Price
+
+// Cost returns the USD cost of a record, or 0 for models without a price.
+func (p Prices) Cost(r Record) float64 {
+	price, ok := p[r.ModelID]
+	if !ok {
+		return 0
+	}
+	return float64(r.InputTokens)/1000*price.InputPer1K +
+		float64(r.OutputTokens)/1000*price.OutputPer1K
+}
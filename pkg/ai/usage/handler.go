@@ -0,0 +1,130 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bexprt/bexgen-client/pkg/api"
+	db "github.com/bexprt/bexgen-client/pkg/database/sql"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const defaultPageSize = 20
+
+// Handler serves the AI usage endpoints of the API from the ai_usage table.
+// It implements the GetAIUsage and GetDocumentAIUsage methods of
+// api.StrictServerInterface.
+type Handler struct {
+	queries db.Querier
+}
+
+func NewHandler(queries db.Querier) *Handler {
+	return &Handler{queries: queries}
+}
+
+// GetAIUsage returns the usage per day and model, the totals per model and
+// a page of the documents, between startDate and endDate inclusive.
+func (h *Handler) GetAIUsage(ctx context.Context, req api.GetAIUsageRequestObject) (api.GetAIUsageResponseObject, error) {
+	start := req.Params.StartDate.Time
+	// endDate is a day; include all of it.
+	end := req.Params.EndDate.Time.AddDate(0, 0, 1).Add(-time.Microsecond)
+	if end.Before(start) {
+		return api.GetAIUsage400JSONResponse{
+			Message: fmt.Sprintf("endDate %s is before startDate %s", req.Params.EndDate, req.Params.StartDate),
+		}, nil
+	}
+
+	pageIndex, pageSize := 1, defaultPageSize
+	if req.Params.PageIndex != nil {
+		pageIndex = *req.Params.PageIndex
+	}
+	if req.Params.PageSize != nil {
+		pageSize = *req.Params.PageSize
+	}
+	if pageIndex < 1 || pageSize < 1 {
+		return api.GetAIUsage400JSONResponse{Message: "pageIndex and pageSize must be positive"}, nil
+	}
+
+	dayRows, err := h.queries.GetUsageByDay(ctx, db.GetUsageByDayParams{StartDate: start, EndDate: end})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage by day: %w", err)
+	}
+	modelRows, err := h.queries.GetUsageByModel(ctx, db.GetUsageByModelParams{StartDate: start, EndDate: end})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage by model: %w", err)
+	}
+	documentRows, err := h.queries.ListDocumentUsage(ctx, db.ListDocumentUsageParams{
+		StartDate:  start,
+		EndDate:    end,
+		PageLimit:  int32(pageSize),
+		PageOffset: int32((pageIndex - 1) * pageSize),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list document usage: %w", err)
+	}
+
+	summary := api.AIUsageSummary{
+		Days:      []api.AIUsageByDay{},
+		Models:    make([]api.AIUsage, 0, len(modelRows)),
+		Documents: make([]api.DocumentAIUsage, 0, len(documentRows)),
+	}
+	// Rows are ordered by day, so the days are built in order.
+	for _, r := range dayRows {
+		if n := len(summary.Days); n == 0 || !summary.Days[n-1].Date.Time.Equal(r.Day) {
+			summary.Days = append(summary.Days, api.AIUsageByDay{Date: openapi_types.Date{Time: r.Day}, Usage: []api.AIUsage{}})
+		}
+		day := &summary.Days[len(summary.Days)-1]
+		day.Usage = append(day.Usage, api.AIUsage{
+			ModelId:      &r.ModelID,
+			Calls:        int(r.Calls),
+			InputTokens:  r.InputTokens,
+			OutputTokens: r.OutputTokens,
+			CostUsd:      r.CostUsd,
+		})
+	}
+	for _, r := range modelRows {
+		summary.Models = append(summary.Models, api.AIUsage{
+			ModelId:      &r.ModelID,
+			Calls:        int(r.Calls),
+			InputTokens:  r.InputTokens,
+			OutputTokens: r.OutputTokens,
+			CostUsd:      r.CostUsd,
+		})
+		summary.TotalCostUsd += r.CostUsd
+	}
+	for _, r := range documentRows {
+		summary.Documents = append(summary.Documents, api.DocumentAIUsage{
+			DocumentId:   r.DocumentID,
+			Calls:        int(r.Calls),
+			InputTokens:  r.InputTokens,
+			OutputTokens: r.OutputTokens,
+			CostUsd:      r.CostUsd,
+		})
+	}
+
+	return api.GetAIUsage200JSONResponse(summary), nil
+}
+
+// GetDocumentAIUsage returns the usage of a document per model and
+// operation.
+func (h *Handler) GetDocumentAIUsage(ctx context.Context, req api.GetDocumentAIUsageRequestObject) (api.GetDocumentAIUsageResponseObject, error) {
+	rows, err := h.queries.GetUsageByDocument(ctx, req.DocumentId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document usage: %w", err)
+	}
+
+	out := make([]api.AIUsage, 0, len(rows))
+	for _, r := range rows {
+		op := api.AIUsageOperation(r.Operation)
+		out = append(out, api.AIUsage{
+			ModelId:      &r.ModelID,
+			Operation:    &op,
+			Calls:        int(r.Calls),
+			InputTokens:  r.InputTokens,
+			OutputTokens: r.OutputTokens,
+			CostUsd:      r.CostUsd,
+		})
+	}
+	return api.GetDocumentAIUsage200JSONResponse(out), nil
+}
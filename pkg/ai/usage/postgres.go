@@ -0,0 +1,39 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/bexprt/bexgen-client/pkg/database/sql"
+)
+
+// PostgresRecorder stores records in the ai_usage table, priced at the time
+// of the call.
+type PostgresRecorder struct {
+	queries db.Querier
+	prices  Prices
+}
+
+var _ Recorder = (*PostgresRecorder)(nil)
+
+func NewPostgresRecorder(queries db.Querier, prices Prices) *PostgresRecorder {
+	return &PostgresRecorder{queries: queries, prices: prices}
+}
+
+func (p *PostgresRecorder) Record(ctx context.Context, r Record) error {
+	err := p.queries.InsertAIUsage(ctx, db.InsertAIUsageParams{
+		DocumentID:    r.DocumentID,
+		ModelID:       r.ModelID,
+		Operation:     r.Operation,
+		InputTokens:   int32(r.InputTokens),
+		OutputTokens:  int32(r.OutputTokens),
+		CostUsd:       p.prices.Cost(r),
+		CreatedAt:     r.Time,
+		PromptName:    r.PromptName,
+		PromptVersion: r.PromptVersion,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert ai usage: %w", err)
+	}
+	return nil
+}
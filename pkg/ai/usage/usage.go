@@ -0,0 +1,123 @@
+// Package usage attributes the token usage of AI calls to documents.
+//
+// Drivers call Report after each model call; the document and prompt are
+// taken from the context set with WithDocumentID and WithPrompt, and records
+// go to the Recorder installed with SetRecorder.
+package usage
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	OperationChat   = "chat"
+	OperationEmbed  = "embed"
+	OperationRerank = "rerank"
+)
+
+type Record struct {
+	// DocumentID is empty for calls made outside of a document context.
+	DocumentID   string
+	ModelID      string
+	Operation    string
+	InputTokens  int
+	OutputTokens int
+	Time         time.Time
+	// PromptName and PromptVersion identify the template the request was
+	// rendered from, if any.
+	PromptName    string
+	PromptVersion string
+}
+
+// Recorder stores usage records. Record is called synchronously from the
+// model call and should not block for long.
+type Recorder interface {
+	Record(ctx context.Context, r Record) error
+}
+
+// RecorderFunc adapts a function to a Recorder.
+type RecorderFunc func(ctx context.Context, r Record) error
+
+func (f RecorderFunc) Record(ctx context.Context, r Record) error {
+	return f(ctx, r)
+}
+
+type documentIDKey struct{}
+
+// WithDocumentID attributes the AI calls made with ctx to a document.
+func WithDocumentID(ctx context.Context, documentID string) context.Context {
+	return context.WithValue(ctx, documentIDKey{}, documentID)
+}
+
+// DocumentID returns the document set with WithDocumentID.
+func DocumentID(ctx context.Context) string {
+	id, _ := ctx.Value(documentIDKey{}).(string)
+	return id
+}
+
+type promptKey struct{}
+
+type promptRef struct {
+	name, version string
+}
+
+// WithPrompt records the prompt template used for the AI calls made with ctx.
+func WithPrompt(ctx context.Context, name, version string) context.Context {
+	return context.WithValue(ctx, promptKey{}, promptRef{name: name, version: version})
+}
+
+// Prompt returns the prompt template set with WithPrompt.
+func Prompt(ctx context.Context) (name, version string) {
+	ref, _ := ctx.Value(promptKey{}).(promptRef)
+	return ref.name, ref.version
+}
+
+type holder struct {
+	r       Recorder
+	onError func(error)
+}
+
+var recorder atomic.Pointer[holder]
+
+// SetRecorder installs the process-wide recorder; nil disables recording.
+// onError receives the recording failures; nil prints them.
+func SetRecorder(r Recorder, onError func(error)) {
+	if r == nil {
+		recorder.Store(nil)
+		return
+	}
+	if onError == nil {
+		onError = func(err error) {
+			fmt.Printf("%v\n", err)
+		}
+	}
+	recorder.Store(&holder{r: r, onError: onError})
+}
+
+// Report fills the document and time of r from ctx and hands it to the
+// recorder. Recording failures never fail the model call.
+func Report(ctx context.Context, r Record) {
+	h := recorder.Load()
+	if h == nil {
+		return
+	}
+
+	if r.DocumentID == "" {
+		r.DocumentID = DocumentID(ctx)
+	}
+	if r.PromptName == "" {
+		r.PromptName, r.PromptVersion = Prompt(ctx)
+	}
+	if r.Time.IsZero() {
+		r.Time = time.Now()
+	}
+
+	// Record even when the call's context was cancelled right after it
+	// completed, the tokens were billed anyway.
+	if err := h.r.Record(context.WithoutCancel(ctx), r); err != nil {
+		h.onError(fmt.Errorf("failed to record ai usage: %w", err))
+	}
+}
@@ -25,104 +25,146 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
-// Defines values for ProcessingStepState.
+// Defines values for AIUsageOperation.
 const (
-	ProcessingStepStateCompleted  ProcessingStepState = "completed"
-	ProcessingStepStateFailed     ProcessingStepState = "failed"
-	ProcessingStepStatePending    ProcessingStepState = "pending"
-	ProcessingStepStateProcessing ProcessingStepState = "processing"
+	Chat   AIUsageOperation = "chat"
+	Embed  AIUsageOperation = "embed"
+	Rerank AIUsageOperation = "rerank"
 )
 
-// Defines values for ListDocumentStatusesParamsState.
+// Defines values for DocumentSourceLang.
 const (
-	ListDocumentStatusesParamsStateCompleted  ListDocumentStatusesParamsState = "completed"
-	ListDocumentStatusesParamsStateFailed     ListDocumentStatusesParamsState = "failed"
-	ListDocumentStatusesParamsStatePending    ListDocumentStatusesParamsState = "pending"
-	ListDocumentStatusesParamsStateProcessing ListDocumentStatusesParamsState = "processing"
+	Ar DocumentSourceLang = "ar"
+	En DocumentSourceLang = "en"
 )
 
-// AuditEvent defines model for AuditEvent.
-type AuditEvent struct {
-	Action    *string                 `json:"action,omitempty"`
-	Actor     *string                 `json:"actor,omitempty"`
-	EventId   *openapi_types.UUID     `json:"event_id,omitempty"`
-	Metadata  *map[string]interface{} `json:"metadata,omitempty"`
-	Resource  *string                 `json:"resource,omitempty"`
-	Timestamp *time.Time              `json:"timestamp,omitempty"`
+// AIUsage defines model for AIUsage.
+type AIUsage struct {
+	Calls        int               `json:"calls"`
+	CostUsd      float64           `json:"costUsd"`
+	InputTokens  int64             `json:"inputTokens"`
+	ModelId      *string           `json:"modelId,omitempty"`
+	Operation    *AIUsageOperation `json:"operation,omitempty"`
+	OutputTokens int64             `json:"outputTokens"`
 }
 
-// AuditResponse defines model for AuditResponse.
-type AuditResponse struct {
-	DocumentId *openapi_types.UUID `json:"document_id,omitempty"`
-	Events     *[]AuditEvent       `json:"events,omitempty"`
+// AIUsageOperation defines model for AIUsage.Operation.
+type AIUsageOperation string
+
+// AIUsageByDay defines model for AIUsageByDay.
+type AIUsageByDay struct {
+	Date  openapi_types.Date `json:"date"`
+	Usage []AIUsage          `json:"usage"`
+}
+
+// AIUsageSummary defines model for AIUsageSummary.
+type AIUsageSummary struct {
+	Days         []AIUsageByDay    `json:"days"`
+	Documents    []DocumentAIUsage `json:"documents"`
+	Models       []AIUsage         `json:"models"`
+	TotalCostUsd float64           `json:"totalCostUsd"`
+}
+
+// DailyProgressOutput defines model for DailyProgressOutput.
+type DailyProgressOutput struct {
+	Date     openapi_types.Date    `json:"date"`
+	Statuses []DailyProgressStatus `json:"statuses"`
+}
+
+// DailyProgressStatus defines model for DailyProgressStatus.
+type DailyProgressStatus struct {
+	Color  *string `json:"color,omitempty"`
+	Count  *int    `json:"count,omitempty"`
+	Status *string `json:"status,omitempty"`
 }
 
 // Document defines model for Document.
 type Document struct {
-	ContentType *string              `json:"content_type,omitempty"`
-	CreatedAt   *time.Time           `json:"created_at,omitempty"`
-	Filename    *string              `json:"filename,omitempty"`
-	Id          *openapi_types.UUID  `json:"id,omitempty"`
-	SizeBytes   *int                 `json:"size_bytes,omitempty"`
-	Summary     *string              `json:"summary,omitempty"`
-	UpdatedAt   *time.Time           `json:"updated_at,omitempty"`
-	UserEmail   *openapi_types.Email `json:"user_email,omitempty"`
+	Bucket         *string `json:"bucket,omitempty"`
+	Classification *struct {
+		Department   *string `json:"department,omitempty"`
+		DocName      *string `json:"docName,omitempty"`
+		Sector       *string `json:"sector,omitempty"`
+		TemplateId   *string `json:"templateId,omitempty"`
+		TemplateName *string `json:"templateName,omitempty"`
+	} `json:"classification,omitempty"`
+	DocumentId              *string             `json:"documentId,omitempty"`
+	Lines                   *[]string           `json:"lines,omitempty"`
+	Name                    *string             `json:"name,omitempty"`
+	SourceLang              *DocumentSourceLang `json:"sourceLang,omitempty"`
+	TransJobId              *string             `json:"transJobId,omitempty"`
+	TranslationOutputFolder *string             `json:"translationOutputFolder,omitempty"`
+}
+
+// DocumentSourceLang defines model for Document.SourceLang.
+type DocumentSourceLang string
+
+// DocumentAIUsage defines model for DocumentAIUsage.
+type DocumentAIUsage struct {
+	Calls        int     `json:"calls"`
+	CostUsd      float64 `json:"costUsd"`
+	DocumentId   string  `json:"documentId"`
+	InputTokens  int64   `json:"inputTokens"`
+	OutputTokens int64   `json:"outputTokens"`
 }
 
 // DocumentStatus defines model for DocumentStatus.
 type DocumentStatus struct {
-	DocumentId *openapi_types.UUID `json:"document_id,omitempty"`
-	Steps      *[]ProcessingStep   `json:"steps,omitempty"`
+	Id         *string    `json:"id,omitempty"`
+	LastUpdate *time.Time `json:"lastUpdate,omitempty"`
+	Name       *string    `json:"name,omitempty"`
+	Status     *string    `json:"status,omitempty"`
+	UploadDate *time.Time `json:"uploadDate,omitempty"`
 }
 
-// DocumentUploadResponse defines model for DocumentUploadResponse.
-type DocumentUploadResponse struct {
-	CreatedAt *time.Time           `json:"created_at,omitempty"`
-	Id        *openapi_types.UUID  `json:"id,omitempty"`
-	Status    *string              `json:"status,omitempty"`
-	UserEmail *openapi_types.Email `json:"user_email,omitempty"`
+// Error defines model for Error.
+type Error struct {
+	Message string `json:"message"`
 }
 
-// ProcessingStep defines model for ProcessingStep.
-type ProcessingStep struct {
-	Message   *string              `json:"message,omitempty"`
-	State     *ProcessingStepState `json:"state,omitempty"`
-	StepName  *string              `json:"step_name,omitempty"`
-	UpdatedAt *time.Time           `json:"updated_at,omitempty"`
-}
-
-// ProcessingStepState defines model for ProcessingStep.State.
-type ProcessingStepState string
-
-// SearchResponse defines model for SearchResponse.
-type SearchResponse struct {
-	Results *[]SearchResult `json:"results,omitempty"`
-	Total   *int            `json:"total,omitempty"`
+// GetDocumentStatusOutput defines model for GetDocumentStatusOutput.
+type GetDocumentStatusOutput struct {
+	Data       *[]DocumentStatus `json:"data,omitempty"`
+	PageCount  *int              `json:"pageCount,omitempty"`
+	PageIndex  *int              `json:"pageIndex,omitempty"`
+	PageSize   *int              `json:"pageSize,omitempty"`
+	TotalCount *int              `json:"totalCount,omitempty"`
 }
 
-// SearchResult defines model for SearchResult.
-type SearchResult struct {
-	Id      *openapi_types.UUID `json:"id,omitempty"`
-	Score   *float32            `json:"score,omitempty"`
-	Snippet *string             `json:"snippet,omitempty"`
-	Summary *string             `json:"summary,omitempty"`
+// StatusDistribution defines model for StatusDistribution.
+type StatusDistribution struct {
+	BgColor *string `json:"bg_color,omitempty"`
+	Color   *string `json:"color,omitempty"`
+	Count   *int    `json:"count,omitempty"`
+	Icon    *string `json:"icon,omitempty"`
+	Status  *string `json:"status,omitempty"`
 }
 
-// VectorSearchRequest defines model for VectorSearchRequest.
-type VectorSearchRequest struct {
-	Text      *string              `json:"text,omitempty"`
-	TopK      *int                 `json:"top_k,omitempty"`
-	UserEmail *openapi_types.Email `json:"user_email,omitempty"`
+// GetAIUsageParams defines parameters for GetAIUsage.
+type GetAIUsageParams struct {
+	StartDate openapi_types.Date `form:"startDate" json:"startDate"`
+	EndDate   openapi_types.Date `form:"endDate" json:"endDate"`
+	PageIndex *int               `form:"pageIndex,omitempty" json:"pageIndex,omitempty"`
+	PageSize  *int               `form:"pageSize,omitempty" json:"pageSize,omitempty"`
 }
 
-// DocumentId defines model for DocumentId.
-type DocumentId = openapi_types.UUID
+// PostAuditJSONBody defines parameters for PostAudit.
+type PostAuditJSONBody struct {
+	ActionAr   *string `json:"action_ar,omitempty"`
+	ActionEn   *string `json:"action_en,omitempty"`
+	DocumentId *string `json:"documentId,omitempty"`
 
-// Limit defines model for Limit.
-type Limit = int
+	// Params Audit parameters (array or object)
+	Params   *map[string]interface{} `json:"params,omitempty"`
+	UserId   *string                 `json:"userId,omitempty"`
+	Username *string                 `json:"username,omitempty"`
+}
 
-// Offset defines model for Offset.
-type Offset = int
+// GetDailyProgressParams defines parameters for GetDailyProgress.
+type GetDailyProgressParams struct {
+	StartDate openapi_types.Date `form:"startDate" json:"startDate"`
+	EndDate   openapi_types.Date `form:"endDate" json:"endDate"`
+}
 
 // UploadDocumentMultipartBody defines parameters for UploadDocument.
 type UploadDocumentMultipartBody struct {
@@ -130,68 +172,74 @@ type UploadDocumentMultipartBody struct {
 	UserEmail openapi_types.Email `json:"user_email"`
 }
 
-// ListDocumentAuditParams defines parameters for ListDocumentAudit.
-type ListDocumentAuditParams struct {
-	DocumentId *openapi_types.UUID `form:"document_id,omitempty" json:"document_id,omitempty"`
-	Action     *string             `form:"action,omitempty" json:"action,omitempty"`
-	Actor      *string             `form:"actor,omitempty" json:"actor,omitempty"`
-	From       *time.Time          `form:"from,omitempty" json:"from,omitempty"`
-	To         *time.Time          `form:"to,omitempty" json:"to,omitempty"`
-	Limit      *Limit              `form:"limit,omitempty" json:"limit,omitempty"`
-	Offset     *Offset             `form:"offset,omitempty" json:"offset,omitempty"`
+// GetSearchParams defines parameters for GetSearch.
+type GetSearchParams struct {
+	K string `form:"k" json:"k"`
 }
 
-// SearchDocumentsParams defines parameters for SearchDocuments.
-type SearchDocumentsParams struct {
-	Query     string               `form:"query" json:"query"`
-	UserEmail *openapi_types.Email `form:"user_email,omitempty" json:"user_email,omitempty"`
-	Limit     *Limit               `form:"limit,omitempty" json:"limit,omitempty"`
-	Offset    *Offset              `form:"offset,omitempty" json:"offset,omitempty"`
+// GetStatusDistributionParams defines parameters for GetStatusDistribution.
+type GetStatusDistributionParams struct {
+	StartDate openapi_types.Date `form:"startDate" json:"startDate"`
+	EndDate   openapi_types.Date `form:"endDate" json:"endDate"`
 }
 
-// ListDocumentStatusesParams defines parameters for ListDocumentStatuses.
-type ListDocumentStatusesParams struct {
-	UserEmail *openapi_types.Email             `form:"user_email,omitempty" json:"user_email,omitempty"`
-	State     *ListDocumentStatusesParamsState `form:"state,omitempty" json:"state,omitempty"`
-	Limit     *Limit                           `form:"limit,omitempty" json:"limit,omitempty"`
-	Offset    *Offset                          `form:"offset,omitempty" json:"offset,omitempty"`
+// GetStatusTableParams defines parameters for GetStatusTable.
+type GetStatusTableParams struct {
+	// Status Comma-separated list of statuses to include
+	Status    string             `form:"status" json:"status"`
+	PageIndex int                `form:"pageIndex" json:"pageIndex"`
+	PageSize  int                `form:"pageSize" json:"pageSize"`
+	StartDate openapi_types.Date `form:"startDate" json:"startDate"`
+	EndDate   openapi_types.Date `form:"endDate" json:"endDate"`
+
+	// Name Filter documents by name (partial match)
+	Name *string `form:"name,omitempty" json:"name,omitempty"`
+
+	// Sort Field name to sort by
+	Sort *string `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// IsDesc Sort descending when true
+	IsDesc *bool `form:"isDesc,omitempty" json:"isDesc,omitempty"`
 }
 
-// ListDocumentStatusesParamsState defines parameters for ListDocumentStatuses.
-type ListDocumentStatusesParamsState string
+// PostAuditJSONRequestBody defines body for PostAudit for application/json ContentType.
+type PostAuditJSONRequestBody PostAuditJSONBody
 
 // UploadDocumentMultipartRequestBody defines body for UploadDocument for multipart/form-data ContentType.
 type UploadDocumentMultipartRequestBody UploadDocumentMultipartBody
 
-// VectorSearchDocumentsJSONRequestBody defines body for VectorSearchDocuments for application/json ContentType.
-type VectorSearchDocumentsJSONRequestBody = VectorSearchRequest
-
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Returns AI token usage and cost per day, per model and per document within a date range.
+	// (GET /ai-usage)
+	GetAIUsage(w http.ResponseWriter, r *http.Request, params GetAIUsageParams)
+	// Returns the list of audit log entries.
+	// (GET /audit)
+	GetAudit(w http.ResponseWriter, r *http.Request)
+	// Creates a new audit entry.
+	// (POST /audit)
+	PostAudit(w http.ResponseWriter, r *http.Request)
+	// Returns daily progress series data for a date range.
+	// (GET /daily-progress)
+	GetDailyProgress(w http.ResponseWriter, r *http.Request, params GetDailyProgressParams)
 	// Upload a document
 	// (POST /documents)
 	UploadDocument(w http.ResponseWriter, r *http.Request)
-	// Get audit logs across documents
-	// (GET /documents/audit)
-	ListDocumentAudit(w http.ResponseWriter, r *http.Request, params ListDocumentAuditParams)
-	// Search documents (text search)
-	// (GET /documents/search)
-	SearchDocuments(w http.ResponseWriter, r *http.Request, params SearchDocumentsParams)
-	// Get processing status for documents
-	// (GET /documents/status)
-	ListDocumentStatuses(w http.ResponseWriter, r *http.Request, params ListDocumentStatusesParams)
-	// Vector similarity search
-	// (POST /documents/vector-search)
-	VectorSearchDocuments(w http.ResponseWriter, r *http.Request)
-	// Get document by ID
-	// (GET /documents/{id})
-	GetDocumentById(w http.ResponseWriter, r *http.Request, id DocumentId)
-	// Get document audit log
-	// (GET /documents/{id}/audit)
-	GetDocumentAudit(w http.ResponseWriter, r *http.Request, id DocumentId)
-	// Get document processing status
-	// (GET /documents/{id}/status)
-	GetDocumentStatus(w http.ResponseWriter, r *http.Request, id DocumentId)
+	// Returns the AI token usage and cost of a document per model and operation.
+	// (GET /documents/{document_id}/ai-usage)
+	GetDocumentAIUsage(w http.ResponseWriter, r *http.Request, documentId string)
+	// Download a document
+	// (GET /documents/{document_id}/download)
+	DownloadDocument(w http.ResponseWriter, r *http.Request, documentId string)
+
+	// (GET /search)
+	GetSearch(w http.ResponseWriter, r *http.Request, params GetSearchParams)
+	// Returns document status distribution counts within a date range.
+	// (GET /status-distribution)
+	GetStatusDistribution(w http.ResponseWriter, r *http.Request, params GetStatusDistributionParams)
+	// Returns a paginated list of documents for the selected statuses and filters.
+	// (GET /status-table)
+	GetStatusTable(w http.ResponseWriter, r *http.Request, params GetStatusTableParams)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -203,86 +251,76 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
-// UploadDocument operation middleware
-func (siw *ServerInterfaceWrapper) UploadDocument(w http.ResponseWriter, r *http.Request) {
-
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UploadDocument(w, r)
-	}))
-
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
-
-	handler.ServeHTTP(w, r)
-}
-
-// ListDocumentAudit operation middleware
-func (siw *ServerInterfaceWrapper) ListDocumentAudit(w http.ResponseWriter, r *http.Request) {
+// GetAIUsage operation middleware
+func (siw *ServerInterfaceWrapper) GetAIUsage(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params ListDocumentAuditParams
+	var params GetAIUsageParams
 
-	// ------------- Optional query parameter "document_id" -------------
+	// ------------- Required query parameter "startDate" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "document_id", r.URL.Query(), &params.DocumentId)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "document_id", Err: err})
+	if paramValue := r.URL.Query().Get("startDate"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "startDate"})
 		return
 	}
 
-	// ------------- Optional query parameter "action" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "action", r.URL.Query(), &params.Action)
+	err = runtime.BindQueryParameter("form", true, true, "startDate", r.URL.Query(), &params.StartDate)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "action", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "startDate", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "actor" -------------
+	// ------------- Required query parameter "endDate" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "actor", r.URL.Query(), &params.Actor)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "actor", Err: err})
+	if paramValue := r.URL.Query().Get("endDate"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "endDate"})
 		return
 	}
 
-	// ------------- Optional query parameter "from" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	err = runtime.BindQueryParameter("form", true, true, "endDate", r.URL.Query(), &params.EndDate)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "endDate", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "to" -------------
+	// ------------- Optional query parameter "pageIndex" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	err = runtime.BindQueryParameter("form", true, false, "pageIndex", r.URL.Query(), &params.PageIndex)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pageIndex", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "limit" -------------
+	// ------------- Optional query parameter "pageSize" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	err = runtime.BindQueryParameter("form", true, false, "pageSize", r.URL.Query(), &params.PageSize)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pageSize", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "offset" -------------
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAIUsage(w, r, params)
+	}))
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
+	handler.ServeHTTP(w, r)
+}
+
+// GetAudit operation middleware
+func (siw *ServerInterfaceWrapper) GetAudit(w http.ResponseWriter, r *http.Request) {
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListDocumentAudit(w, r, params)
+		siw.Handler.GetAudit(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -292,55 +330,74 @@ func (siw *ServerInterfaceWrapper) ListDocumentAudit(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// SearchDocuments operation middleware
-func (siw *ServerInterfaceWrapper) SearchDocuments(w http.ResponseWriter, r *http.Request) {
+// PostAudit operation middleware
+func (siw *ServerInterfaceWrapper) PostAudit(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAudit(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDailyProgress operation middleware
+func (siw *ServerInterfaceWrapper) GetDailyProgress(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params SearchDocumentsParams
+	var params GetDailyProgressParams
 
-	// ------------- Required query parameter "query" -------------
+	// ------------- Required query parameter "startDate" -------------
 
-	if paramValue := r.URL.Query().Get("query"); paramValue != "" {
+	if paramValue := r.URL.Query().Get("startDate"); paramValue != "" {
 
 	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "query"})
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "startDate"})
 		return
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "query", r.URL.Query(), &params.Query)
+	err = runtime.BindQueryParameter("form", true, true, "startDate", r.URL.Query(), &params.StartDate)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "query", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "startDate", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "user_email" -------------
+	// ------------- Required query parameter "endDate" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "user_email", r.URL.Query(), &params.UserEmail)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_email", Err: err})
+	if paramValue := r.URL.Query().Get("endDate"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "endDate"})
 		return
 	}
 
-	// ------------- Optional query parameter "limit" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	err = runtime.BindQueryParameter("form", true, true, "endDate", r.URL.Query(), &params.EndDate)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "endDate", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "offset" -------------
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDailyProgress(w, r, params)
+	}))
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
+	handler.ServeHTTP(w, r)
+}
+
+// UploadDocument operation middleware
+func (siw *ServerInterfaceWrapper) UploadDocument(w http.ResponseWriter, r *http.Request) {
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.SearchDocuments(w, r, params)
+		siw.Handler.UploadDocument(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -350,48 +407,47 @@ func (siw *ServerInterfaceWrapper) SearchDocuments(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-// ListDocumentStatuses operation middleware
-func (siw *ServerInterfaceWrapper) ListDocumentStatuses(w http.ResponseWriter, r *http.Request) {
+// GetDocumentAIUsage operation middleware
+func (siw *ServerInterfaceWrapper) GetDocumentAIUsage(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ListDocumentStatusesParams
+	// ------------- Path parameter "document_id" -------------
+	var documentId string
 
-	// ------------- Optional query parameter "user_email" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "user_email", r.URL.Query(), &params.UserEmail)
+	err = runtime.BindStyledParameterWithOptions("simple", "document_id", r.PathValue("document_id"), &documentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_email", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "document_id", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "state" -------------
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDocumentAIUsage(w, r, documentId)
+	}))
 
-	err = runtime.BindQueryParameter("form", true, false, "state", r.URL.Query(), &params.State)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "state", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	// ------------- Optional query parameter "limit" -------------
+	handler.ServeHTTP(w, r)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
-		return
-	}
+// DownloadDocument operation middleware
+func (siw *ServerInterfaceWrapper) DownloadDocument(w http.ResponseWriter, r *http.Request) {
+
+	var err error
 
-	// ------------- Optional query parameter "offset" -------------
+	// ------------- Path parameter "document_id" -------------
+	var documentId string
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	err = runtime.BindStyledParameterWithOptions("simple", "document_id", r.PathValue("document_id"), &documentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "document_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListDocumentStatuses(w, r, params)
+		siw.Handler.DownloadDocument(w, r, documentId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -401,11 +457,31 @@ func (siw *ServerInterfaceWrapper) ListDocumentStatuses(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// VectorSearchDocuments operation middleware
-func (siw *ServerInterfaceWrapper) VectorSearchDocuments(w http.ResponseWriter, r *http.Request) {
+// GetSearch operation middleware
+func (siw *ServerInterfaceWrapper) GetSearch(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSearchParams
+
+	// ------------- Required query parameter "k" -------------
+
+	if paramValue := r.URL.Query().Get("k"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "k"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "k", r.URL.Query(), &params.K)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "k", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.VectorSearchDocuments(w, r)
+		siw.Handler.GetSearch(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -415,22 +491,46 @@ func (siw *ServerInterfaceWrapper) VectorSearchDocuments(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// GetDocumentById operation middleware
-func (siw *ServerInterfaceWrapper) GetDocumentById(w http.ResponseWriter, r *http.Request) {
+// GetStatusDistribution operation middleware
+func (siw *ServerInterfaceWrapper) GetStatusDistribution(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "id" -------------
-	var id DocumentId
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStatusDistributionParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Required query parameter "startDate" -------------
+
+	if paramValue := r.URL.Query().Get("startDate"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "startDate"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "startDate", r.URL.Query(), &params.StartDate)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "startDate", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "endDate" -------------
+
+	if paramValue := r.URL.Query().Get("endDate"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "endDate"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "endDate", r.URL.Query(), &params.EndDate)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "endDate", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetDocumentById(w, r, id)
+		siw.Handler.GetStatusDistribution(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -440,47 +540,115 @@ func (siw *ServerInterfaceWrapper) GetDocumentById(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-// GetDocumentAudit operation middleware
-func (siw *ServerInterfaceWrapper) GetDocumentAudit(w http.ResponseWriter, r *http.Request) {
+// GetStatusTable operation middleware
+func (siw *ServerInterfaceWrapper) GetStatusTable(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "id" -------------
-	var id DocumentId
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStatusTableParams
+
+	// ------------- Required query parameter "status" -------------
+
+	if paramValue := r.URL.Query().Get("status"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "status"})
+		return
+	}
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindQueryParameter("form", true, true, "status", r.URL.Query(), &params.Status)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
 		return
 	}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetDocumentAudit(w, r, id)
-	}))
+	// ------------- Required query parameter "pageIndex" -------------
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	if paramValue := r.URL.Query().Get("pageIndex"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "pageIndex"})
+		return
 	}
 
-	handler.ServeHTTP(w, r)
-}
+	err = runtime.BindQueryParameter("form", true, true, "pageIndex", r.URL.Query(), &params.PageIndex)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pageIndex", Err: err})
+		return
+	}
 
-// GetDocumentStatus operation middleware
-func (siw *ServerInterfaceWrapper) GetDocumentStatus(w http.ResponseWriter, r *http.Request) {
+	// ------------- Required query parameter "pageSize" -------------
 
-	var err error
+	if paramValue := r.URL.Query().Get("pageSize"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "pageSize"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "pageSize", r.URL.Query(), &params.PageSize)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pageSize", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "startDate" -------------
+
+	if paramValue := r.URL.Query().Get("startDate"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "startDate"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "startDate", r.URL.Query(), &params.StartDate)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "startDate", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "endDate" -------------
+
+	if paramValue := r.URL.Query().Get("endDate"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "endDate"})
+		return
+	}
 
-	// ------------- Path parameter "id" -------------
-	var id DocumentId
+	err = runtime.BindQueryParameter("form", true, true, "endDate", r.URL.Query(), &params.EndDate)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "endDate", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "name", r.URL.Query(), &params.Name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "isDesc" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "isDesc", r.URL.Query(), &params.IsDesc)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "isDesc", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetDocumentStatus(w, r, id)
+		siw.Handler.GetStatusTable(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -610,162 +778,210 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	m.HandleFunc("GET "+options.BaseURL+"/ai-usage", wrapper.GetAIUsage)
+	m.HandleFunc("GET "+options.BaseURL+"/audit", wrapper.GetAudit)
+	m.HandleFunc("POST "+options.BaseURL+"/audit", wrapper.PostAudit)
+	m.HandleFunc("GET "+options.BaseURL+"/daily-progress", wrapper.GetDailyProgress)
 	m.HandleFunc("POST "+options.BaseURL+"/documents", wrapper.UploadDocument)
-	m.HandleFunc("GET "+options.BaseURL+"/documents/audit", wrapper.ListDocumentAudit)
-	m.HandleFunc("GET "+options.BaseURL+"/documents/search", wrapper.SearchDocuments)
-	m.HandleFunc("GET "+options.BaseURL+"/documents/status", wrapper.ListDocumentStatuses)
-	m.HandleFunc("POST "+options.BaseURL+"/documents/vector-search", wrapper.VectorSearchDocuments)
-	m.HandleFunc("GET "+options.BaseURL+"/documents/{id}", wrapper.GetDocumentById)
-	m.HandleFunc("GET "+options.BaseURL+"/documents/{id}/audit", wrapper.GetDocumentAudit)
-	m.HandleFunc("GET "+options.BaseURL+"/documents/{id}/status", wrapper.GetDocumentStatus)
+	m.HandleFunc("GET "+options.BaseURL+"/documents/{document_id}/ai-usage", wrapper.GetDocumentAIUsage)
+	m.HandleFunc("GET "+options.BaseURL+"/documents/{document_id}/download", wrapper.DownloadDocument)
+	m.HandleFunc("GET "+options.BaseURL+"/search", wrapper.GetSearch)
+	m.HandleFunc("GET "+options.BaseURL+"/status-distribution", wrapper.GetStatusDistribution)
+	m.HandleFunc("GET "+options.BaseURL+"/status-table", wrapper.GetStatusTable)
 
 	return m
 }
 
-type UploadDocumentRequestObject struct {
-	Body *multipart.Reader
+type GetAIUsageRequestObject struct {
+	Params GetAIUsageParams
 }
 
-type UploadDocumentResponseObject interface {
-	VisitUploadDocumentResponse(w http.ResponseWriter) error
+type GetAIUsageResponseObject interface {
+	VisitGetAIUsageResponse(w http.ResponseWriter) error
 }
 
-type UploadDocument201JSONResponse DocumentUploadResponse
+type GetAIUsage200JSONResponse AIUsageSummary
 
-func (response UploadDocument201JSONResponse) VisitUploadDocumentResponse(w http.ResponseWriter) error {
+func (response GetAIUsage200JSONResponse) VisitGetAIUsageResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAIUsage400JSONResponse Error
+
+func (response GetAIUsage400JSONResponse) VisitGetAIUsageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListDocumentAuditRequestObject struct {
-	Params ListDocumentAuditParams
+type GetAuditRequestObject struct {
 }
 
-type ListDocumentAuditResponseObject interface {
-	VisitListDocumentAuditResponse(w http.ResponseWriter) error
+type GetAuditResponseObject interface {
+	VisitGetAuditResponse(w http.ResponseWriter) error
 }
 
-type ListDocumentAudit200JSONResponse struct {
-	Results *[]AuditEvent `json:"results,omitempty"`
-	Total   *int          `json:"total,omitempty"`
+type GetAudit200JSONResponse []struct {
+	ActionAr     *string    `json:"actionAr,omitempty"`
+	ActionEn     *string    `json:"actionEn,omitempty"`
+	CreationDate *time.Time `json:"creationDate,omitempty"`
+	Id           *string    `json:"id,omitempty"`
+	Params       *string    `json:"params,omitempty"`
+	UserId       *string    `json:"userId,omitempty"`
+	UserName     *string    `json:"userName,omitempty"`
 }
 
-func (response ListDocumentAudit200JSONResponse) VisitListDocumentAuditResponse(w http.ResponseWriter) error {
+func (response GetAudit200JSONResponse) VisitGetAuditResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type SearchDocumentsRequestObject struct {
-	Params SearchDocumentsParams
+type PostAuditRequestObject struct {
+	Body *PostAuditJSONRequestBody
 }
 
-type SearchDocumentsResponseObject interface {
-	VisitSearchDocumentsResponse(w http.ResponseWriter) error
+type PostAuditResponseObject interface {
+	VisitPostAuditResponse(w http.ResponseWriter) error
 }
 
-type SearchDocuments200JSONResponse SearchResponse
+type PostAudit200Response struct {
+}
 
-func (response SearchDocuments200JSONResponse) VisitSearchDocumentsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
+func (response PostAudit200Response) VisitPostAuditResponse(w http.ResponseWriter) error {
 	w.WriteHeader(200)
-
-	return json.NewEncoder(w).Encode(response)
+	return nil
 }
 
-type ListDocumentStatusesRequestObject struct {
-	Params ListDocumentStatusesParams
+type GetDailyProgressRequestObject struct {
+	Params GetDailyProgressParams
 }
 
-type ListDocumentStatusesResponseObject interface {
-	VisitListDocumentStatusesResponse(w http.ResponseWriter) error
+type GetDailyProgressResponseObject interface {
+	VisitGetDailyProgressResponse(w http.ResponseWriter) error
 }
 
-type ListDocumentStatuses200JSONResponse struct {
-	Results *[]DocumentStatus `json:"results,omitempty"`
-	Total   *int              `json:"total,omitempty"`
-}
+type GetDailyProgress200JSONResponse []DailyProgressOutput
 
-func (response ListDocumentStatuses200JSONResponse) VisitListDocumentStatusesResponse(w http.ResponseWriter) error {
+func (response GetDailyProgress200JSONResponse) VisitGetDailyProgressResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type VectorSearchDocumentsRequestObject struct {
-	Body *VectorSearchDocumentsJSONRequestBody
+type UploadDocumentRequestObject struct {
+	Body *multipart.Reader
 }
 
-type VectorSearchDocumentsResponseObject interface {
-	VisitVectorSearchDocumentsResponse(w http.ResponseWriter) error
+type UploadDocumentResponseObject interface {
+	VisitUploadDocumentResponse(w http.ResponseWriter) error
 }
 
-type VectorSearchDocuments200JSONResponse SearchResponse
+type UploadDocument201Response struct {
+}
 
-func (response VectorSearchDocuments200JSONResponse) VisitVectorSearchDocumentsResponse(w http.ResponseWriter) error {
+func (response UploadDocument201Response) VisitUploadDocumentResponse(w http.ResponseWriter) error {
+	w.WriteHeader(201)
+	return nil
+}
+
+type GetDocumentAIUsageRequestObject struct {
+	DocumentId string `json:"document_id"`
+}
+
+type GetDocumentAIUsageResponseObject interface {
+	VisitGetDocumentAIUsageResponse(w http.ResponseWriter) error
+}
+
+type GetDocumentAIUsage200JSONResponse []AIUsage
+
+func (response GetDocumentAIUsage200JSONResponse) VisitGetDocumentAIUsageResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetDocumentByIdRequestObject struct {
-	Id DocumentId `json:"id"`
+type DownloadDocumentRequestObject struct {
+	DocumentId string `json:"document_id"`
 }
 
-type GetDocumentByIdResponseObject interface {
-	VisitGetDocumentByIdResponse(w http.ResponseWriter) error
+type DownloadDocumentResponseObject interface {
+	VisitDownloadDocumentResponse(w http.ResponseWriter) error
 }
 
-type GetDocumentById200JSONResponse Document
+type DownloadDocument200JSONResponse struct {
+	// Content Base64-encoded file content
+	Content []byte `json:"content"`
+	Name    string `json:"name"`
+}
 
-func (response GetDocumentById200JSONResponse) VisitGetDocumentByIdResponse(w http.ResponseWriter) error {
+func (response DownloadDocument200JSONResponse) VisitDownloadDocumentResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetDocumentById404Response struct {
+type DownloadDocument404Response struct {
 }
 
-func (response GetDocumentById404Response) VisitGetDocumentByIdResponse(w http.ResponseWriter) error {
+func (response DownloadDocument404Response) VisitDownloadDocumentResponse(w http.ResponseWriter) error {
 	w.WriteHeader(404)
 	return nil
 }
 
-type GetDocumentAuditRequestObject struct {
-	Id DocumentId `json:"id"`
+type GetSearchRequestObject struct {
+	Params GetSearchParams
+}
+
+type GetSearchResponseObject interface {
+	VisitGetSearchResponse(w http.ResponseWriter) error
+}
+
+type GetSearch200JSONResponse []Document
+
+func (response GetSearch200JSONResponse) VisitGetSearchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetStatusDistributionRequestObject struct {
+	Params GetStatusDistributionParams
 }
 
-type GetDocumentAuditResponseObject interface {
-	VisitGetDocumentAuditResponse(w http.ResponseWriter) error
+type GetStatusDistributionResponseObject interface {
+	VisitGetStatusDistributionResponse(w http.ResponseWriter) error
 }
 
-type GetDocumentAudit200JSONResponse AuditResponse
+type GetStatusDistribution200JSONResponse []StatusDistribution
 
-func (response GetDocumentAudit200JSONResponse) VisitGetDocumentAuditResponse(w http.ResponseWriter) error {
+func (response GetStatusDistribution200JSONResponse) VisitGetStatusDistributionResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetDocumentStatusRequestObject struct {
-	Id DocumentId `json:"id"`
+type GetStatusTableRequestObject struct {
+	Params GetStatusTableParams
 }
 
-type GetDocumentStatusResponseObject interface {
-	VisitGetDocumentStatusResponse(w http.ResponseWriter) error
+type GetStatusTableResponseObject interface {
+	VisitGetStatusTableResponse(w http.ResponseWriter) error
 }
 
-type GetDocumentStatus200JSONResponse DocumentStatus
+type GetStatusTable200JSONResponse GetDocumentStatusOutput
 
-func (response GetDocumentStatus200JSONResponse) VisitGetDocumentStatusResponse(w http.ResponseWriter) error {
+func (response GetStatusTable200JSONResponse) VisitGetStatusTableResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
@@ -774,30 +990,36 @@ func (response GetDocumentStatus200JSONResponse) VisitGetDocumentStatusResponse(
 
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// Returns AI token usage and cost per day, per model and per document within a date range.
+	// (GET /ai-usage)
+	GetAIUsage(ctx context.Context, request GetAIUsageRequestObject) (GetAIUsageResponseObject, error)
+	// Returns the list of audit log entries.
+	// (GET /audit)
+	GetAudit(ctx context.Context, request GetAuditRequestObject) (GetAuditResponseObject, error)
+	// Creates a new audit entry.
+	// (POST /audit)
+	PostAudit(ctx context.Context, request PostAuditRequestObject) (PostAuditResponseObject, error)
+	// Returns daily progress series data for a date range.
+	// (GET /daily-progress)
+	GetDailyProgress(ctx context.Context, request GetDailyProgressRequestObject) (GetDailyProgressResponseObject, error)
 	// Upload a document
 	// (POST /documents)
 	UploadDocument(ctx context.Context, request UploadDocumentRequestObject) (UploadDocumentResponseObject, error)
-	// Get audit logs across documents
-	// (GET /documents/audit)
-	ListDocumentAudit(ctx context.Context, request ListDocumentAuditRequestObject) (ListDocumentAuditResponseObject, error)
-	// Search documents (text search)
-	// (GET /documents/search)
-	SearchDocuments(ctx context.Context, request SearchDocumentsRequestObject) (SearchDocumentsResponseObject, error)
-	// Get processing status for documents
-	// (GET /documents/status)
-	ListDocumentStatuses(ctx context.Context, request ListDocumentStatusesRequestObject) (ListDocumentStatusesResponseObject, error)
-	// Vector similarity search
-	// (POST /documents/vector-search)
-	VectorSearchDocuments(ctx context.Context, request VectorSearchDocumentsRequestObject) (VectorSearchDocumentsResponseObject, error)
-	// Get document by ID
-	// (GET /documents/{id})
-	GetDocumentById(ctx context.Context, request GetDocumentByIdRequestObject) (GetDocumentByIdResponseObject, error)
-	// Get document audit log
-	// (GET /documents/{id}/audit)
-	GetDocumentAudit(ctx context.Context, request GetDocumentAuditRequestObject) (GetDocumentAuditResponseObject, error)
-	// Get document processing status
-	// (GET /documents/{id}/status)
-	GetDocumentStatus(ctx context.Context, request GetDocumentStatusRequestObject) (GetDocumentStatusResponseObject, error)
+	// Returns the AI token usage and cost of a document per model and operation.
+	// (GET /documents/{document_id}/ai-usage)
+	GetDocumentAIUsage(ctx context.Context, request GetDocumentAIUsageRequestObject) (GetDocumentAIUsageResponseObject, error)
+	// Download a document
+	// (GET /documents/{document_id}/download)
+	DownloadDocument(ctx context.Context, request DownloadDocumentRequestObject) (DownloadDocumentResponseObject, error)
+
+	// (GET /search)
+	GetSearch(ctx context.Context, request GetSearchRequestObject) (GetSearchResponseObject, error)
+	// Returns document status distribution counts within a date range.
+	// (GET /status-distribution)
+	GetStatusDistribution(ctx context.Context, request GetStatusDistributionRequestObject) (GetStatusDistributionResponseObject, error)
+	// Returns a paginated list of documents for the selected statuses and filters.
+	// (GET /status-table)
+	GetStatusTable(ctx context.Context, request GetStatusTableRequestObject) (GetStatusTableResponseObject, error)
 }
 
 type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
@@ -829,30 +1051,49 @@ type strictHandler struct {
 	options     StrictHTTPServerOptions
 }
 
-// UploadDocument operation middleware
-func (sh *strictHandler) UploadDocument(w http.ResponseWriter, r *http.Request) {
-	var request UploadDocumentRequestObject
+// GetAIUsage operation middleware
+func (sh *strictHandler) GetAIUsage(w http.ResponseWriter, r *http.Request, params GetAIUsageParams) {
+	var request GetAIUsageRequestObject
 
-	if reader, err := r.MultipartReader(); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode multipart body: %w", err))
-		return
-	} else {
-		request.Body = reader
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAIUsage(ctx, request.(GetAIUsageRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAIUsage")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAIUsageResponseObject); ok {
+		if err := validResponse.VisitGetAIUsageResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
 	}
+}
+
+// GetAudit operation middleware
+func (sh *strictHandler) GetAudit(w http.ResponseWriter, r *http.Request) {
+	var request GetAuditRequestObject
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.UploadDocument(ctx, request.(UploadDocumentRequestObject))
+		return sh.ssi.GetAudit(ctx, request.(GetAuditRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "UploadDocument")
+		handler = middleware(handler, "GetAudit")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(UploadDocumentResponseObject); ok {
-		if err := validResponse.VisitUploadDocumentResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetAuditResponseObject); ok {
+		if err := validResponse.VisitGetAuditResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -860,25 +1101,30 @@ func (sh *strictHandler) UploadDocument(w http.ResponseWriter, r *http.Request)
 	}
 }
 
-// ListDocumentAudit operation middleware
-func (sh *strictHandler) ListDocumentAudit(w http.ResponseWriter, r *http.Request, params ListDocumentAuditParams) {
-	var request ListDocumentAuditRequestObject
+// PostAudit operation middleware
+func (sh *strictHandler) PostAudit(w http.ResponseWriter, r *http.Request) {
+	var request PostAuditRequestObject
 
-	request.Params = params
+	var body PostAuditJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListDocumentAudit(ctx, request.(ListDocumentAuditRequestObject))
+		return sh.ssi.PostAudit(ctx, request.(PostAuditRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListDocumentAudit")
+		handler = middleware(handler, "PostAudit")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListDocumentAuditResponseObject); ok {
-		if err := validResponse.VisitListDocumentAuditResponse(w); err != nil {
+	} else if validResponse, ok := response.(PostAuditResponseObject); ok {
+		if err := validResponse.VisitPostAuditResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -886,25 +1132,25 @@ func (sh *strictHandler) ListDocumentAudit(w http.ResponseWriter, r *http.Reques
 	}
 }
 
-// SearchDocuments operation middleware
-func (sh *strictHandler) SearchDocuments(w http.ResponseWriter, r *http.Request, params SearchDocumentsParams) {
-	var request SearchDocumentsRequestObject
+// GetDailyProgress operation middleware
+func (sh *strictHandler) GetDailyProgress(w http.ResponseWriter, r *http.Request, params GetDailyProgressParams) {
+	var request GetDailyProgressRequestObject
 
 	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.SearchDocuments(ctx, request.(SearchDocumentsRequestObject))
+		return sh.ssi.GetDailyProgress(ctx, request.(GetDailyProgressRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "SearchDocuments")
+		handler = middleware(handler, "GetDailyProgress")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(SearchDocumentsResponseObject); ok {
-		if err := validResponse.VisitSearchDocumentsResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetDailyProgressResponseObject); ok {
+		if err := validResponse.VisitGetDailyProgressResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -912,25 +1158,30 @@ func (sh *strictHandler) SearchDocuments(w http.ResponseWriter, r *http.Request,
 	}
 }
 
-// ListDocumentStatuses operation middleware
-func (sh *strictHandler) ListDocumentStatuses(w http.ResponseWriter, r *http.Request, params ListDocumentStatusesParams) {
-	var request ListDocumentStatusesRequestObject
+// UploadDocument operation middleware
+func (sh *strictHandler) UploadDocument(w http.ResponseWriter, r *http.Request) {
+	var request UploadDocumentRequestObject
 
-	request.Params = params
+	if reader, err := r.MultipartReader(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode multipart body: %w", err))
+		return
+	} else {
+		request.Body = reader
+	}
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListDocumentStatuses(ctx, request.(ListDocumentStatusesRequestObject))
+		return sh.ssi.UploadDocument(ctx, request.(UploadDocumentRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListDocumentStatuses")
+		handler = middleware(handler, "UploadDocument")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListDocumentStatusesResponseObject); ok {
-		if err := validResponse.VisitListDocumentStatusesResponse(w); err != nil {
+	} else if validResponse, ok := response.(UploadDocumentResponseObject); ok {
+		if err := validResponse.VisitUploadDocumentResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -938,30 +1189,51 @@ func (sh *strictHandler) ListDocumentStatuses(w http.ResponseWriter, r *http.Req
 	}
 }
 
-// VectorSearchDocuments operation middleware
-func (sh *strictHandler) VectorSearchDocuments(w http.ResponseWriter, r *http.Request) {
-	var request VectorSearchDocumentsRequestObject
+// GetDocumentAIUsage operation middleware
+func (sh *strictHandler) GetDocumentAIUsage(w http.ResponseWriter, r *http.Request, documentId string) {
+	var request GetDocumentAIUsageRequestObject
 
-	var body VectorSearchDocumentsJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
+	request.DocumentId = documentId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDocumentAIUsage(ctx, request.(GetDocumentAIUsageRequestObject))
 	}
-	request.Body = &body
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDocumentAIUsage")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetDocumentAIUsageResponseObject); ok {
+		if err := validResponse.VisitGetDocumentAIUsageResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DownloadDocument operation middleware
+func (sh *strictHandler) DownloadDocument(w http.ResponseWriter, r *http.Request, documentId string) {
+	var request DownloadDocumentRequestObject
+
+	request.DocumentId = documentId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.VectorSearchDocuments(ctx, request.(VectorSearchDocumentsRequestObject))
+		return sh.ssi.DownloadDocument(ctx, request.(DownloadDocumentRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "VectorSearchDocuments")
+		handler = middleware(handler, "DownloadDocument")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(VectorSearchDocumentsResponseObject); ok {
-		if err := validResponse.VisitVectorSearchDocumentsResponse(w); err != nil {
+	} else if validResponse, ok := response.(DownloadDocumentResponseObject); ok {
+		if err := validResponse.VisitDownloadDocumentResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -969,25 +1241,25 @@ func (sh *strictHandler) VectorSearchDocuments(w http.ResponseWriter, r *http.Re
 	}
 }
 
-// GetDocumentById operation middleware
-func (sh *strictHandler) GetDocumentById(w http.ResponseWriter, r *http.Request, id DocumentId) {
-	var request GetDocumentByIdRequestObject
+// GetSearch operation middleware
+func (sh *strictHandler) GetSearch(w http.ResponseWriter, r *http.Request, params GetSearchParams) {
+	var request GetSearchRequestObject
 
-	request.Id = id
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetDocumentById(ctx, request.(GetDocumentByIdRequestObject))
+		return sh.ssi.GetSearch(ctx, request.(GetSearchRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetDocumentById")
+		handler = middleware(handler, "GetSearch")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetDocumentByIdResponseObject); ok {
-		if err := validResponse.VisitGetDocumentByIdResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetSearchResponseObject); ok {
+		if err := validResponse.VisitGetSearchResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -995,25 +1267,25 @@ func (sh *strictHandler) GetDocumentById(w http.ResponseWriter, r *http.Request,
 	}
 }
 
-// GetDocumentAudit operation middleware
-func (sh *strictHandler) GetDocumentAudit(w http.ResponseWriter, r *http.Request, id DocumentId) {
-	var request GetDocumentAuditRequestObject
+// GetStatusDistribution operation middleware
+func (sh *strictHandler) GetStatusDistribution(w http.ResponseWriter, r *http.Request, params GetStatusDistributionParams) {
+	var request GetStatusDistributionRequestObject
 
-	request.Id = id
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetDocumentAudit(ctx, request.(GetDocumentAuditRequestObject))
+		return sh.ssi.GetStatusDistribution(ctx, request.(GetStatusDistributionRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetDocumentAudit")
+		handler = middleware(handler, "GetStatusDistribution")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetDocumentAuditResponseObject); ok {
-		if err := validResponse.VisitGetDocumentAuditResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetStatusDistributionResponseObject); ok {
+		if err := validResponse.VisitGetStatusDistributionResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1021,25 +1293,25 @@ func (sh *strictHandler) GetDocumentAudit(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// GetDocumentStatus operation middleware
-func (sh *strictHandler) GetDocumentStatus(w http.ResponseWriter, r *http.Request, id DocumentId) {
-	var request GetDocumentStatusRequestObject
+// GetStatusTable operation middleware
+func (sh *strictHandler) GetStatusTable(w http.ResponseWriter, r *http.Request, params GetStatusTableParams) {
+	var request GetStatusTableRequestObject
 
-	request.Id = id
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetDocumentStatus(ctx, request.(GetDocumentStatusRequestObject))
+		return sh.ssi.GetStatusTable(ctx, request.(GetStatusTableRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetDocumentStatus")
+		handler = middleware(handler, "GetStatusTable")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetDocumentStatusResponseObject); ok {
-		if err := validResponse.VisitGetDocumentStatusResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetStatusTableResponseObject); ok {
+		if err := validResponse.VisitGetStatusTableResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1050,27 +1322,35 @@ func (sh *strictHandler) GetDocumentStatus(w http.ResponseWriter, r *http.Reques
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xY3W4bNxN9lQW/76IFNpLcpje6c+AiMBCgRoPmxjEEejkrM1n+mBwaUQ29e0Fyf7Vc",
-	"eWUnhdE7SSRnh2fOnDmrR1IooZUEiZasH4mmhgpAMOHbhSqcAImXzH/jkqyJpnhHciKpALImnJGcGLh3",
-	"3AAjazQOcmKLOxDUnyiVERTJmjgXduJO+1MWDZdbst/n5AMXHNvg9w7MrotehcV+QAYldRWS9dmqjcYl",
-	"whZMCPdHWVqYjKfiajJgKt6+2RnAOHeM4+8PIEN8bZQGgxzCGi2QK+k/HVwx90vKJFfAx9pwNgOpnAhA",
-	"yiiGpClj3D+PVle9LCL49UF1+wUK9AcNWOVMAckUkAuwSIUe5MAowhu/lCzZ6AkBmD/BaiUtjLFhNYnm",
-	"3jTAEk5yBBE+/N9ASdbkf8uOrMu6MsteWbrkqDF0l8624fQ40UJJ9HnGIwm0CgMUgW0ozoUrJyWvINIv",
-	"EXAmIpb/DZvbHcY0D2maE+uEoGaXfITT7OScnQWzAUF5NTgTf5lFiQbkj0jR2ZdzwiLo+ZS4MqoAa7nc",
-	"fkTQp9HiL10pyqbZ/BwOzL5kAxZ8o0JXftWFdID9mDIdADW6rABr6TbNXZ9tWAHpBFlfEw2S+bXcB6nD",
-	"kjzMlwowXKGkvAJGbiYqvJlslNNZnLruR6CmuJuurQHrqhOkp43nJ8iIZTlBhbRKtezR5MI4OkxtLoUK",
-	"ZfoISidua42QXOs4GsenJvUjlegn8BOtSffegU3ki/At/SxUevN1MHl/yxOS9lJu+5+4LFV8ki0M13FC",
-	"k/Ory6xUJoudxeU2z2y4S/hIJcsElXTL5TareQwsa/TKfpZcFpXz57KHAER9Os9i+2ZoaPG1jUX9dMoq",
-	"tbWLz9InzjH0dSM3WdeC2fnVJcnJAxgbMz1brBYrD4bSIKnmZE1+XZwtVr7DKN4FnJdtZqEGKtbCV4L6",
-	"+3rjRqKktXMvGjaw+E6xXW/uhYZ3FXJNDS495G8aw9H5pWGV/XAb1OeWSxoc18vFqnOV1/E5gxg3yYoP",
-	"nWg0P6HXQ7a/rM4Orku1rngRkFp+sdHAdXc91voT4yJkMeRbW+lGyjPrCl/y0lVVHEZt/9WlymhLuLDe",
-	"FXkZ+OST20Ki0h+4xeZ5wRcFqnSO/jrti/vT+CT7ng5Xm+F+pPknlXnOwdIokU796IhIB0P1rFApunTg",
-	"L+ObzoyN9TvM/mZE39VJ9H3ZbDtmq0+abOOOOG80MQOJxuc3bIL3gD3dzGhhlLWdBB+2RNTfyZ6Ig+qi",
-	"PT2rI5qv06+2M7nUk6wkpyb17/XxaZYTmpTBuCNraDiseL3Y1jT7yfuHerL+PCp465OfFMH4/gEzq/6C",
-	"YqXCRYfcj/Q9rPJ/T2oOXha/t9x4PmSqbNlVG7Wk7HTlaOyct4qT0hMd4JtOgdIGrO+Y+0I07cOe34kp",
-	"dz7LG/2bYvCp75wnNKHZwwWvqOG4q7cf1uCRs/2kFryHVgre7S7ZWAaeaJDeH6A/VD8vOrs3bSAZIOVV",
-	"aJC3q7fjN5t2o1SYlcpJlmB42wW3u+zyIgXmEx6zB+mExXwlmA7/lnymD2nhag1JErInRlIPs1rkXjsR",
-	"Gy0+QseRVh6DL7XZ7wbz0CDgTEXW5A5R2/VySTVf1H+DLQollg9nZH+z/ycAAP//FxAlkK8YAAA=",
+	"H4sIAAAAAAAC/+xZQW/juhH+KwTbw3uAYidt8A6+ZeO3hYuiDZrNaTcIaHFsc0ORWnKUrBv4vxckJVmS",
+	"ScXe7KLboqcIJjkznG++meHkhea6KLUChZbOXqjNN1Aw/3m1uLNsDe6zNLoEgwL8Qs6k9B+4LYHOqFAI",
+	"azB0l9FcW7yz3C2utCkY0hnlulpKoFmzXVXFMuwWqqzwg34EZXsnhMLfLmkWkV9oDnLBO8otGqHWbs2Z",
+	"yFBo5VZBVQWdfaT5hiHNKBRL4DSjBgxTj/Q+ixyv8DRrdk7cl0oY4F6T90r/UgOpe//sDdDLz5CjM6D2",
+	"97vtnG0Pnc4ZQt+t7ofIPaoGNIFQ+KN/NLCiM/qH6R7qaY3ztAF510pixrDtwe1qbUH4iPW3VVEwE7V/",
+	"a0+1KrjiwLSMcp1XRROyR0mc1yeS961jy77dcxlFjUxen0CFA2dvLW0N6l53IDsGxJwJub0xem3A2n/4",
+	"8HtDNFlkWFk4wdFd9bf+9LHB1ep69Vq13MPMpKU20eSQ60phPGfZVtjg1C5mRg3Foe5llT8CxpVLZq1Y",
+	"ibxNTwM0oGQGG7EHx7nO/84KiK5ZyDFxZYSilAxhwUeXE6Jjl2/iMCFRCjUIlEOlA6ao5L10ZXL4G1Pr",
+	"bjZnhmYUVDR/o2HK/lUvU9d1y9IDEEjxXksO5kTYf3xNfMXHp5fMt9a1jkHZ24tc48gUg0UitJjFuzKa",
+	"tc5QFNHUlY6tFOEzWpVSMz4/QU8sWn43RpvDuxVgm+A5FNJ1ebMx5sC/APZ9OJLi2cnlMZWwM1qyNVyn",
+	"k6hbXigOX9PLt+JfEF+ta1pCeMzDwc65cA5cVvGsulw/jNWDb6gUIteqs3JERB3avvMkXumQ921uRBnM",
+	"p1c3C7LShoQYFGqdEQvM5Bv/yRQnBVNsLdSalEbnYC1w0rYGn5RQuazcOfLki0J9OiPBOoKG5Y+tLFZx",
+	"gUTqtZ18UjSjKFA6O5tIIDdBh5N3dbOgGX0CY4OlF5PzyXndcitWCjqjf55cTM6pAxo33hFTJs7aVnQd",
+	"CmPbobvk5iK5SafunGEFIBhLZx9fqHBqvlRgtrQhsnOywXloFfZsQVNBVj9bjuhodllcOCj+o0TvqdEV",
+	"VgglClfVLmIZOC3Js+hoQffuPrbUqm7h/nR+HvokhXW3wcpS1o3J9LMN8b0XfkQD3HT8PrL7Ee3XiWe3",
+	"9aGNGyCGqdA1X35HW0LGjZiwUE9MCk4cZEE10YaUvnF3xK2Nn9F/AlZGWXK1IOgKGfHB66ni6hkpwRDO",
+	"tpn/8J25X/M/N5R5FrgRirCOtolXM/VsG2WC3/BGtNpU30+ELHf7r+LpLiz+Hs9ruQGva35S4U3UcE/x",
+	"RNm1YBY8uXRCizp4ZxzEw5VbIHq1z38EFBoBdpIICBezUlhMHcpoqW0E1BttO6h+qcDiO823JwEaw/GB",
+	"jQH5ACr1hhhrK/fYDNzlL7xPzuQXFhxoSPD5rzQCwitwqmPh3O2G6XgXZ0jMaIfQlhjIteHAh+heu8gG",
+	"SxhR8EzY/kRNWO6em2dl/d4cY27vYfo/Usnuv1ciOnpSUHexRzDYHyMNMsSC42GTnhMc5tEzrkP2ZSmS",
+	"sHsTpjjB78Jbod44yvKikihKZnDqPH/WtOYpoq+E7KfbpVDMYxzl0wMUTMjegfBL9so7w+vpybj/Jg5e",
+	"HHKwbSNDNwuc2CrPwdpVJeUQpuBJB0N9aoDB9KX5fBB8d1RnOXywx3nputU9czpKRtnzH2FLelA73nbp",
+	"la9gPc/Gi1yq83F1b9/j9Nuf1u+Tcci4flYO4yRk83pDh04/L2DDoWN7rI/DO2bht8szULl2BHBsI83m",
+	"rEPuLY7OL+ArK0r/Nqu/JiVfvcptfzprjYsTO8FZbymzZOkvEHr1yxGOK41kpSvFB9HVgHrA7PAyHePv",
+	"bdhxVDl9/PnY2obxEXQNVyUGbCXRuh3eRf7RfsYHI46kvw4HIv9vRY6GK+K9U94S9YClixUJehPtSEOc",
+	"2EE/f7Ijb8k6MpAtJbweEh9YGDQPYqF/k2tdFOzMgtuEwNv3TvMfGYKahPES0CwKddh5EhGPGJekhb1x",
+	"fPJ9Bf83TKb6gL8XEjuTC0uWW+L0kV9KZlAwSQqG+ebXBNr+zyvYDhWC5EEFamK1QbLcpmJJGzxN+q2T",
+	"534C5QegzxtQxDkpoUHYOdg8pmOptQSmfvDoLDXEHyvKda7w1HTJwQ2xhPLaSQHI/KMinm9Ys7dD7T30",
+	"zWDOgoTcbWlZ7zq8lY8UPx1xssE8NSmkMpLO6AaxtLPpdAlf16AmuS6mTxd0d7/79wD1NNteWiIAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
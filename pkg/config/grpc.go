@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GRPCTarget configures one entry of the GRPC map. A plain string is
+// shorthand for the address alone:
+//
+//	GRPC:
+//	  ocr: localhost:50051
+//	  classifier:
+//	    address: classifier:443
+//	    tls:
+//	      enabled: true
+type GRPCTarget struct {
+	Address string `yaml:"address"`
+
+	TLS struct {
+		Enabled            bool   `yaml:"enabled"`
+		CAFile             string `yaml:"ca_file"`
+		CertFile           string `yaml:"cert_file"`
+		KeyFile            string `yaml:"key_file"`
+		ServerName         string `yaml:"server_name"`
+		InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+	} `yaml:"tls"`
+
+	Keepalive struct {
+		Time                time.Duration `yaml:"time"`
+		Timeout             time.Duration `yaml:"timeout"`
+		PermitWithoutStream bool          `yaml:"permit_without_stream"`
+	} `yaml:"keepalive"`
+
+	// Timeout is the default deadline of every call; MethodTimeouts overrides
+	// it per method name, e.g. PerformOCR.
+	Timeout        time.Duration            `yaml:"timeout"`
+	MethodTimeouts map[string]time.Duration `yaml:"method_timeouts"`
+
+	Retry struct {
+		MaxAttempts          int           `yaml:"max_attempts"`
+		InitialBackoff       time.Duration `yaml:"initial_backoff"`
+		MaxBackoff           time.Duration `yaml:"max_backoff"`
+		BackoffMultiplier    float64       `yaml:"backoff_multiplier"`
+		RetryableStatusCodes []string      `yaml:"retryable_status_codes"`
+	} `yaml:"retry"`
+}
+
+func (t *GRPCTarget) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind == yaml.ScalarNode {
+		t.Address = n.Value
+		return nil
+	}
+
+	type plain GRPCTarget
+	if err := n.Decode((*plain)(t)); err != nil {
+		return err
+	}
+	if t.Address == "" {
+		return fmt.Errorf("line %d: address is required", n.Line)
+	}
+	return nil
+}
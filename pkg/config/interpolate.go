@@ -0,0 +1,124 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const secretFilePrefix = "file://"
+
+// interpolate resolves ${VAR}, ${VAR:-default} and file:// references in every
+// scalar value of the YAML tree. Mapping keys are left untouched. All missing
+// variables and unreadable files are reported together.
+func interpolate(n *yaml.Node) error {
+	var errs []error
+	walkValues(n, func(s *yaml.Node) {
+		value, err := expandValue(s.Value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", s.Line, err))
+			return
+		}
+		if value == s.Value {
+			return
+		}
+		s.Value = value
+		// Let plain scalars resolve their type again, so `port: ${PORT}`
+		// still decodes as an int. Quoted scalars stay strings.
+		if s.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+			s.Tag = ""
+		}
+	})
+	return errors.Join(errs...)
+}
+
+func walkValues(n *yaml.Node, fn func(*yaml.Node)) {
+	switch n.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, c := range n.Content {
+			walkValues(c, fn)
+		}
+	case yaml.MappingNode:
+		for i := 1; i < len(n.Content); i += 2 {
+			walkValues(n.Content[i], fn)
+		}
+	case yaml.ScalarNode:
+		fn(n)
+	}
+}
+
+func expandValue(s string) (string, error) {
+	s, err := expandEnv(s)
+	if err != nil {
+		return "", err
+	}
+
+	if path, ok := strings.CutPrefix(s, secretFilePrefix); ok {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret file: %w", err)
+		}
+		return strings.TrimRight(string(b), "\r\n"), nil
+	}
+
+	return s, nil
+}
+
+// expandEnv replaces ${VAR} and ${VAR:-default} references. "$${" escapes a
+// literal "${".
+func expandEnv(s string) (string, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+
+	var (
+		b       strings.Builder
+		missing []string
+	)
+
+	for {
+		i := strings.Index(s, "${")
+		if i < 0 {
+			b.WriteString(s)
+			break
+		}
+		if i > 0 && s[i-1] == '$' {
+			b.WriteString(s[:i])
+			b.WriteString("{")
+			s = s[i+2:]
+			continue
+		}
+
+		end := strings.IndexByte(s[i:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated variable reference in %q", s)
+		}
+
+		b.WriteString(s[:i])
+		expr := s[i+2 : i+end]
+		s = s[i+end+1:]
+
+		name, def, hasDef := strings.Cut(expr, ":-")
+		if name == "" {
+			return "", fmt.Errorf("empty variable reference")
+		}
+
+		v, ok := os.LookupEnv(name)
+		switch {
+		case hasDef && v == "":
+			v = def
+		case !ok:
+			missing = append(missing, name)
+		}
+		b.WriteString(v)
+	}
+
+	if len(missing) > 0 {
+		return "", fmt.Errorf("environment variable %s is not set", strings.Join(missing, ", "))
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("INTERP_TEST_HOST", "db.local")
+	t.Setenv("INTERP_TEST_EMPTY", "")
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"no reference", "plain $HOST", "plain $HOST"},
+		{"set", "${INTERP_TEST_HOST}:5432", "db.local:5432"},
+		{"escaped", "$${INTERP_TEST_HOST}", "${INTERP_TEST_HOST}"},
+		{"escaped and set", "$${A} ${INTERP_TEST_HOST}", "${A} db.local"},
+		{"default when unset", "${INTERP_TEST_UNSET:-fallback}", "fallback"},
+		{"default when empty", "${INTERP_TEST_EMPTY:-fallback}", "fallback"},
+		{"default ignored when set", "${INTERP_TEST_HOST:-fallback}", "db.local"},
+		{"empty default", "${INTERP_TEST_UNSET:-}", ""},
+		{"empty without default", "${INTERP_TEST_EMPTY}", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandEnv(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("expandEnv(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandEnvErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"missing variables together", "${INTERP_TEST_A}/${INTERP_TEST_B}", "INTERP_TEST_A, INTERP_TEST_B is not set"},
+		{"unterminated", "prefix ${INTERP_TEST_A", "unterminated variable reference"},
+		{"empty name", "${}", "empty variable reference"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := expandEnv(tt.in)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expandEnv(%q) error = %v, want %q", tt.in, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandValueSecretFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(path, []byte("s3cr3t\r\n\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("INTERP_TEST_SECRET", path)
+
+	got, err := expandValue("file://${INTERP_TEST_SECRET}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "s3cr3t" {
+		t.Fatalf("expandValue = %q, want the trimmed file content", got)
+	}
+
+	if _, err := expandValue("file://" + filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatal("expected an error for a missing secret file")
+	}
+}
+
+func TestInterpolateRetypesPlainScalars(t *testing.T) {
+	t.Setenv("INTERP_TEST_PORT", "5432")
+
+	var doc yaml.Node
+	src := "plain: ${INTERP_TEST_PORT}\nquoted: \"${INTERP_TEST_PORT}\"\n"
+	if err := yaml.Unmarshal([]byte(src), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if err := interpolate(&doc); err != nil {
+		t.Fatal(err)
+	}
+
+	var out map[string]any
+	if err := doc.Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out["plain"] != 5432 {
+		t.Fatalf("plain = %#v, want the int 5432", out["plain"])
+	}
+	if out["quoted"] != "5432" {
+		t.Fatalf("quoted = %#v, want the string \"5432\"", out["quoted"])
+	}
+}
+
+func TestInterpolateReportsLines(t *testing.T) {
+	var doc yaml.Node
+	src := "a: ${INTERP_TEST_A}\nb: ok\nc: ${INTERP_TEST_C}\n"
+	if err := yaml.Unmarshal([]byte(src), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	err := interpolate(&doc)
+	if err == nil {
+		t.Fatal("expected missing variables to fail")
+	}
+	for _, want := range []string{"line 1: environment variable INTERP_TEST_A", "line 3: environment variable INTERP_TEST_C"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error %q does not contain %q", err, want)
+		}
+	}
+}
@@ -12,17 +12,70 @@ type FactoryConfig struct {
 	Driver     string         `yaml:"driver"`
 	ConfigPath string         `yaml:"-"`
 	Options    map[string]any `yaml:"options"`
+
+	node    *yaml.Node
+	decoded any
 }
 
 type RootYAML struct {
-	Storage   *FactoryConfig    `yaml:"storage"`
-	Messaging *FactoryConfig    `yaml:"messaging"`
-	Search    *FactoryConfig    `yaml:"search"`
-	Embedding *FactoryConfig    `yaml:"embedding"`
-	Model     *FactoryConfig    `yaml:"model"`
-	Rerank    *FactoryConfig    `yaml:"rerank"`
-	SQL       *FactoryConfig    `yaml:"Sql"`
-	GRPC      map[string]string `yaml:"GRPC"`
+	Storage   *FactoryConfig `yaml:"storage"`
+	Messaging *FactoryConfig `yaml:"messaging"`
+	Search    *FactoryConfig `yaml:"search"`
+	Embedding *FactoryConfig `yaml:"embedding"`
+	Model     *FactoryConfig `yaml:"model"`
+	Rerank    *FactoryConfig `yaml:"rerank"`
+	SQL       *FactoryConfig `yaml:"Sql"`
+	// GRPCTargets is the GRPC map with the dial settings of every service.
+	GRPCTargets map[string]GRPCTarget `yaml:"GRPC"`
+	// GRPC holds the address of every service of the GRPC map.
+	//
+	// Deprecated: use GRPCTargets. LoadConfig still fills it, and
+	// addresses set only here are used with default dial settings.
+	GRPC map[string]string `yaml:"-"`
+}
+
+// Targets returns the GRPC targets, including the services that are only
+// set in the deprecated GRPC map.
+func (r *RootYAML) Targets() map[string]GRPCTarget {
+	targets := make(map[string]GRPCTarget, len(r.GRPCTargets)+len(r.GRPC))
+	for key, address := range r.GRPC {
+		targets[key] = GRPCTarget{Address: address}
+	}
+	for key, target := range r.GRPCTargets {
+		targets[key] = target
+	}
+	return targets
+}
+
+var sectionNames = []string{
+	SectionStorage,
+	SectionMessaging,
+	SectionSearch,
+	SectionEmbedding,
+	SectionModel,
+	SectionRerank,
+	SectionSQL,
+}
+
+func (r *RootYAML) section(name string) *FactoryConfig {
+	switch name {
+	case SectionStorage:
+		return r.Storage
+	case SectionMessaging:
+		return r.Messaging
+	case SectionSearch:
+		return r.Search
+	case SectionEmbedding:
+		return r.Embedding
+	case SectionModel:
+		return r.Model
+	case SectionRerank:
+		return r.Rerank
+	case SectionSQL:
+		return r.SQL
+	default:
+		return nil
+	}
 }
 
 const defaultConfigPath = "/etc/bexgen/config.yaml"
@@ -38,34 +91,76 @@ func normalizeDriver(d string) string {
 	return nd
 }
 
-func LoadConfig(path string) (*RootYAML, error) {
+// LoadConfig reads the base config file at path and deep-merges the overlay
+// of every profile on top of it, in order. The overlay of profile "prod" for
+// config.yaml is config.prod.yaml in the same directory. Without explicit
+// profiles, the comma-separated BEXGEN_PROFILE variable is used. BEXGEN_
+// environment overrides are applied last.
+//
+// Driver options are validated here only for drivers that have called
+// RegisterOptions, i.e. whose packages the binary imports. Options of other
+// sections are validated when their driver is constructed, since every
+// constructor decodes its options with FactoryConfig.DecodeOptions.
+func LoadConfig(path string, profiles ...string) (*RootYAML, error) {
 	if path == "" {
 		path = defaultConfigPath
 	}
+	if len(profiles) == 0 {
+		profiles = envProfiles()
+	}
 
-	file, err := os.ReadFile(path)
+	doc, err := readYAML(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
+		return nil, err
+	}
+
+	for _, profile := range profiles {
+		overlay, err := readYAML(profilePath(path, profile))
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: %w", profile, err)
+		}
+		merge(doc, overlay)
+	}
+
+	if err := applyEnvOverrides(doc, os.Environ()); err != nil {
+		return nil, fmt.Errorf("failed to apply environment overrides: %w", err)
+	}
+
+	if err := interpolate(doc); err != nil {
+		return nil, fmt.Errorf("failed to interpolate config: %w", err)
 	}
 
 	var root RootYAML
-	if err := yaml.Unmarshal(file, &root); err != nil {
+	if err := doc.Decode(&root); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal YAML: %w", err)
 	}
 
 	cfg := root.Storage
-	if cfg.Driver == "" {
+	if cfg == nil || cfg.Driver == "" {
 		return nil, fmt.Errorf("storage.driver is required")
 	}
 
-	normalizeDriver(root.Messaging.Driver)
-	normalizeDriver(root.Storage.Driver)
+	for _, name := range sectionNames {
+		f := root.section(name)
+		if f == nil {
+			continue
+		}
+		f.ConfigPath = path
+		normalizeDriver(f.Driver)
+		if f.Options == nil {
+			f.Options = make(map[string]any)
+		}
+	}
 
-	if root.Messaging.Options == nil {
-		root.Messaging.Options = make(map[string]any)
+	if len(root.GRPCTargets) > 0 {
+		root.GRPC = make(map[string]string, len(root.GRPCTargets))
+		for key, target := range root.GRPCTargets {
+			root.GRPC[key] = target.Address
+		}
 	}
-	if root.Storage.Options == nil {
-		root.Storage.Options = make(map[string]any)
+
+	if err := validateOptions(&root); err != nil {
+		return nil, fmt.Errorf("invalid configuration:\n%w", err)
 	}
 
 	return &root, nil
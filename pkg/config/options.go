@@ -0,0 +1,276 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	SectionStorage   = "storage"
+	SectionMessaging = "messaging"
+	SectionSearch    = "search"
+	SectionEmbedding = "embedding"
+	SectionModel     = "model"
+	SectionRerank    = "rerank"
+	SectionSQL       = "Sql"
+)
+
+// Validator is implemented by option structs that need checks beyond the
+// `required:"true"` field tag.
+type Validator interface {
+	Validate() error
+}
+
+var (
+	schemasMu sync.RWMutex
+	schemas   = map[string]map[string]func() any{}
+)
+
+// RegisterOptions declares the typed options of a driver. newOptions must
+// return a pointer to a struct using `yaml` tags; fields tagged
+// `required:"true"` must be set to a non-zero value. Options of registered
+// drivers are decoded and validated by LoadConfig; drivers should register
+// from an init function so that their schema is known before LoadConfig.
+func RegisterOptions(section, driver string, newOptions func() any) {
+	schemasMu.Lock()
+	defer schemasMu.Unlock()
+
+	if schemas[section] == nil {
+		schemas[section] = map[string]func() any{}
+	}
+	schemas[section][driver] = newOptions
+}
+
+func lookupOptions(section, driver string) (func() any, bool) {
+	schemasMu.RLock()
+	defer schemasMu.RUnlock()
+
+	newOptions, ok := schemas[section][driver]
+	return newOptions, ok
+}
+
+// UnmarshalYAML keeps the raw options node so that decoding errors point at
+// the original file lines.
+func (f *FactoryConfig) UnmarshalYAML(n *yaml.Node) error {
+	type plain FactoryConfig
+	if err := n.Decode((*plain)(f)); err != nil {
+		return err
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == "options" {
+			f.node = n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// DecodeOptions decodes the driver options into out, which must be a pointer
+// to a struct. Unknown keys, mismatched types, missing required fields and
+// Validator failures are all reported in a single error.
+func (f *FactoryConfig) DecodeOptions(out any) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("options target must be a pointer to a struct, got %T", out)
+	}
+
+	if f.decoded != nil && reflect.TypeOf(f.decoded) == rv.Type() {
+		rv.Elem().Set(reflect.ValueOf(f.decoded).Elem())
+		return nil
+	}
+
+	n := f.node
+	if n == nil || f.Options == nil {
+		n = &yaml.Node{}
+		if err := n.Encode(f.Options); err != nil {
+			return fmt.Errorf("failed to encode options: %w", err)
+		}
+	}
+
+	var errs []error
+	if n.Kind == yaml.MappingNode {
+		errs = append(errs, unknownKeys(n, rv.Elem().Type(), "")...)
+	}
+
+	if err := n.Decode(out); err != nil {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return err
+		}
+		for _, e := range typeErr.Errors {
+			errs = append(errs, errors.New(e))
+		}
+	}
+
+	errs = append(errs, validate(rv.Elem(), "")...)
+	if v, ok := out.(Validator); ok {
+		if err := v.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ValidateOptions decodes the options of f with the schema its driver
+// registered for section, and keeps the result for the driver constructor.
+// It is a no-op for unregistered drivers. Options that nest driver configs,
+// such as the model router backends, call it from their Validate method.
+func ValidateOptions(section string, f *FactoryConfig) error {
+	newOptions, ok := lookupOptions(section, f.Driver)
+	if !ok {
+		return nil
+	}
+
+	opts := newOptions()
+	if err := f.DecodeOptions(opts); err != nil {
+		return err
+	}
+	f.decoded = opts
+	return nil
+}
+
+// validateOptions decodes the options of every section whose driver has
+// registered a schema, and keeps the result for the driver constructors.
+// Sections of unregistered drivers are left to DecodeOptions at construction,
+// so that a binary doesn't need every driver package to load a shared file.
+func validateOptions(root *RootYAML) error {
+	var errs []error
+	for _, name := range sectionNames {
+		f := root.section(name)
+		if f == nil || f.Driver == "" {
+			continue
+		}
+		if err := ValidateOptions(name, f); err != nil {
+			for _, e := range unwrapJoined(err) {
+				errs = append(errs, fmt.Errorf("%s.options: %w", name, e))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func unwrapJoined(err error) []error {
+	if j, ok := err.(interface{ Unwrap() []error }); ok {
+		return j.Unwrap()
+	}
+	return []error{err}
+}
+
+func unknownKeys(n *yaml.Node, t reflect.Type, path string) []error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Slice && n.Kind == yaml.SequenceNode {
+		var errs []error
+		for i, c := range n.Content {
+			errs = append(errs, unknownKeys(c, t.Elem(), fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return errs
+	}
+	if t.Kind() != reflect.Struct || n.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	fields := map[string]reflect.Type{}
+	collectFields(t, fields)
+
+	var errs []error
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		ft, ok := fields[key.Value]
+		if !ok {
+			errs = append(errs, fmt.Errorf("line %d: unknown key %q", key.Line, joinPath(path, key.Value)))
+			continue
+		}
+		errs = append(errs, unknownKeys(value, ft, joinPath(path, key.Value))...)
+	}
+	return errs
+}
+
+func collectFields(t reflect.Type, fields map[string]reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, inline, skip := yamlField(sf)
+		if skip {
+			continue
+		}
+		if inline && sf.Type.Kind() == reflect.Struct {
+			collectFields(sf.Type, fields)
+			continue
+		}
+		fields[name] = sf.Type
+	}
+}
+
+func validate(v reflect.Value, path string) []error {
+	var errs []error
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, inline, skip := yamlField(sf)
+		if skip {
+			continue
+		}
+
+		fieldPath := joinPath(path, name)
+		if inline {
+			fieldPath = path
+		}
+
+		fv := v.Field(i)
+		if sf.Tag.Get("required") == "true" && fv.IsZero() {
+			errs = append(errs, fmt.Errorf("%s is required", fieldPath))
+			continue
+		}
+
+		if fv.Kind() == reflect.Pointer && !fv.IsNil() {
+			fv = fv.Elem()
+		}
+		if fv.Kind() != reflect.Struct {
+			continue
+		}
+		errs = append(errs, validate(fv, fieldPath)...)
+		if fv.CanAddr() {
+			if val, ok := fv.Addr().Interface().(Validator); ok {
+				if err := val.Validate(); err != nil {
+					if fieldPath != "" {
+						err = fmt.Errorf("%s: %w", fieldPath, err)
+					}
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+	return errs
+}
+
+func yamlField(sf reflect.StructField) (name string, inline, skip bool) {
+	tag := sf.Tag.Get("yaml")
+	if tag == "-" {
+		return "", false, true
+	}
+	name, flags, _ := strings.Cut(tag, ",")
+	inline = strings.Contains(flags, "inline")
+	if name == "" {
+		name = strings.ToLower(sf.Name)
+	}
+	return name, inline, false
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
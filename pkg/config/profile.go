@@ -0,0 +1,216 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	envPrefix     = "BEXGEN_"
+	envProfileVar = envPrefix + "PROFILE"
+	envPathSep    = "__"
+)
+
+func envProfiles() []string {
+	var profiles []string
+	for _, p := range strings.Split(os.Getenv(envProfileVar), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			profiles = append(profiles, p)
+		}
+	}
+	return profiles
+}
+
+// profilePath returns the overlay file of a profile, e.g. config.prod.yaml for
+// config.yaml.
+func profilePath(path, profile string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + profile + ext
+}
+
+func readYAML(path string) (*yaml.Node, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(file, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal YAML %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}},
+		}
+	}
+	return &doc, nil
+}
+
+// merge deep-merges src into dst. Mappings are merged key by key; scalars and
+// sequences in src replace the ones in dst.
+func merge(dst, src *yaml.Node) {
+	if dst.Kind == yaml.DocumentNode && src.Kind == yaml.DocumentNode {
+		merge(dst.Content[0], src.Content[0])
+		return
+	}
+	if dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode {
+		*dst = *src
+		return
+	}
+
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, value := src.Content[i], src.Content[i+1]
+		if existing := mappingValue(dst, key.Value, false); existing != nil {
+			merge(existing, value)
+			continue
+		}
+		dst.Content = append(dst.Content, key, value)
+	}
+}
+
+func mappingValue(n *yaml.Node, key string, foldCase bool) *yaml.Node {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k := n.Content[i].Value
+		if k == key || (foldCase && strings.EqualFold(k, key)) {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// applyEnvOverrides sets individual keys from BEXGEN_ variables, using "__"
+// as the path separator: BEXGEN_STORAGE__OPTIONS__BUCKET overrides
+// storage.options.bucket. Path segments match existing keys and the yaml names
+// of RootYAML and of the registered driver options case-insensitively, and
+// are lowercased otherwise. Values starting with '[' or '{' are parsed as
+// YAML flow collections.
+func applyEnvOverrides(doc *yaml.Node, environ []string) error {
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("config root must be a mapping")
+	}
+
+	for _, kv := range environ {
+		name, value, _ := strings.Cut(kv, "=")
+		rest, ok := strings.CutPrefix(name, envPrefix)
+		if !ok {
+			continue
+		}
+		path := strings.Split(rest, envPathSep)
+		if len(path) < 2 {
+			continue
+		}
+
+		node, err := overrideNode(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		if err := setPath(root, path, node); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func overrideNode(value string) (*yaml.Node, error) {
+	if strings.HasPrefix(value, "[") || strings.HasPrefix(value, "{") {
+		var doc yaml.Node
+		if err := yaml.Unmarshal([]byte(value), &doc); err != nil {
+			return nil, err
+		}
+		return doc.Content[0], nil
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Value: value}, nil
+}
+
+// schemaKey returns the key of t, a struct or map type, matching segment and
+// the type of its value. Struct fields, including inline ones, are matched
+// case-insensitively against their yaml names; other segments are
+// lowercased.
+func schemaKey(t reflect.Type, segment string) (string, reflect.Type) {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return strings.ToLower(segment), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		fields := map[string]reflect.Type{}
+		collectFields(t, fields)
+		for name, ft := range fields {
+			if strings.EqualFold(name, segment) {
+				return name, ft
+			}
+		}
+	case reflect.Map:
+		return strings.ToLower(segment), t.Elem()
+	}
+	return strings.ToLower(segment), nil
+}
+
+// optionsType returns the registered options type of the driver selected in
+// the section node n, or nil.
+func optionsType(section string, n *yaml.Node) reflect.Type {
+	driver := mappingValue(n, "driver", false)
+	if driver == nil {
+		return nil
+	}
+	newOptions, ok := lookupOptions(section, driver.Value)
+	if !ok {
+		return nil
+	}
+	return reflect.TypeOf(newOptions())
+}
+
+// setPath sets the value at path, creating missing mappings. Keys are
+// resolved against RootYAML and the options schema of the section's driver,
+// so that BEXGEN_MODEL__OPTIONS__MAXTOKENS creates maxTokens.
+func setPath(n *yaml.Node, path []string, value *yaml.Node) error {
+	var (
+		t       = reflect.TypeOf(RootYAML{})
+		section string
+	)
+	for i, segment := range path {
+		if n.Kind != yaml.MappingNode {
+			return fmt.Errorf("%s is not a mapping", strings.Join(path[:i], "."))
+		}
+
+		key, kt := schemaKey(t, segment)
+		if i == 0 {
+			section = key
+		}
+		if i == 1 && key == "options" && kt != nil {
+			kt = optionsType(section, n)
+		}
+
+		next := mappingValue(n, key, false)
+		if next == nil {
+			next = mappingValue(n, segment, true)
+		}
+		if next == nil {
+			next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			n.Content = append(n.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: key},
+				next,
+			)
+		}
+
+		if i == len(path)-1 {
+			*next = *value
+			return nil
+		}
+		if next.Kind == yaml.ScalarNode && next.Tag == "!!null" {
+			*next = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+		n, t = next, kt
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrClosed is returned by Reloadable.Use after Close.
+var ErrClosed = errors.New("config: reloadable client is closed")
+
+// Reloadable holds a client built from the configuration and rebuilds it when
+// its section changes. The previous client is closed once the calls that were
+// using it through Use have returned.
+type Reloadable[T any] struct {
+	build func(ctx context.Context, cfg *RootYAML) (T, error)
+	close func(T) error
+
+	current     atomic.Pointer[generation[T]]
+	swapMu      sync.Mutex
+	closed      bool
+	unsubscribe func()
+}
+
+type generation[T any] struct {
+	value  T
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewReloadable builds the client from the watcher's current configuration
+// and subscribes to section. close may be nil.
+func NewReloadable[T any](
+	ctx context.Context,
+	w *Watcher,
+	section string,
+	build func(ctx context.Context, cfg *RootYAML) (T, error),
+	close func(T) error,
+) (*Reloadable[T], error) {
+	value, err := build(ctx, w.Config())
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Reloadable[T]{build: build, close: close}
+	r.current.Store(&generation[T]{value: value})
+
+	r.unsubscribe = w.Subscribe(section, func(ctx context.Context, cfg *RootYAML) error {
+		value, err := build(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		return r.swap(&generation[T]{value: value})
+	})
+
+	return r, nil
+}
+
+// Use calls fn with the current client. The client is not closed by a reload
+// while fn runs; the reload waits for it instead.
+func (r *Reloadable[T]) Use(fn func(T) error) error {
+	for {
+		g := r.current.Load()
+		g.mu.RLock()
+		if g.closed {
+			g.mu.RUnlock()
+			if r.current.Load() == g {
+				return ErrClosed
+			}
+			// Swapped and closed between Load and RLock; retry with the new one.
+			continue
+		}
+		err := fn(g.value)
+		g.mu.RUnlock()
+		return err
+	}
+}
+
+// Close unsubscribes from the watcher and closes the current client. Later
+// calls to Use return ErrClosed.
+func (r *Reloadable[T]) Close() error {
+	r.unsubscribe()
+
+	r.swapMu.Lock()
+	defer r.swapMu.Unlock()
+
+	r.closed = true
+	return r.retire(r.current.Load())
+}
+
+// swap installs next, or closes it when a reload raced with Close.
+func (r *Reloadable[T]) swap(next *generation[T]) error {
+	r.swapMu.Lock()
+	defer r.swapMu.Unlock()
+
+	if r.closed {
+		return r.retire(next)
+	}
+	prev := r.current.Swap(next)
+	return r.retire(prev)
+}
+
+// retire waits for the in-flight calls of g and closes its client.
+func (r *Reloadable[T]) retire(g *generation[T]) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.closed {
+		return nil
+	}
+	g.closed = true
+	if r.close == nil {
+		return nil
+	}
+	return r.close(g.value)
+}
@@ -0,0 +1,187 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeConfig(t *testing.T, path, bucket string) {
+	t.Helper()
+	data := fmt.Sprintf("storage:\n  driver: test\n  options:\n    bucket: %s\n", bucket)
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestWatcher(t *testing.T) (*Watcher, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "a")
+
+	w, err := NewWatcher(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w, path
+}
+
+func bucketOf(cfg *RootYAML) string {
+	b, _ := cfg.Storage.Options["bucket"].(string)
+	return b
+}
+
+func TestWatcherRetriesFailedSubscriber(t *testing.T) {
+	ctx := context.Background()
+	w, path := newTestWatcher(t)
+
+	var calls int
+	fail := true
+	w.Subscribe(SectionStorage, func(ctx context.Context, cfg *RootYAML) error {
+		calls++
+		if fail {
+			return errors.New("rebuild failed")
+		}
+		return nil
+	})
+
+	writeConfig(t, path, "b")
+	if err := w.Reload(ctx); err == nil {
+		t.Fatal("expected the subscriber error")
+	}
+	if got := bucketOf(w.Config()); got != "b" {
+		t.Fatalf("Config() bucket = %q, want b", got)
+	}
+
+	fail = false
+	if err := w.Reload(ctx); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("subscriber called %d times, want 2", calls)
+	}
+
+	if err := w.Reload(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Fatalf("subscriber called again without a change: %d calls", calls)
+	}
+}
+
+func TestWatcherUnsubscribe(t *testing.T) {
+	ctx := context.Background()
+	w, path := newTestWatcher(t)
+
+	var calls int
+	unsubscribe := w.Subscribe(SectionStorage, func(ctx context.Context, cfg *RootYAML) error {
+		calls++
+		return nil
+	})
+	unsubscribe()
+
+	writeConfig(t, path, "b")
+	if err := w.Reload(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 0 {
+		t.Fatalf("subscriber called %d times after unsubscribe", calls)
+	}
+}
+
+type testClient struct {
+	bucket string
+	closed bool
+}
+
+func newTestReloadable(t *testing.T, w *Watcher) (*Reloadable[*testClient], *[]*testClient) {
+	t.Helper()
+	var built []*testClient
+	r, err := NewReloadable(context.Background(), w, SectionStorage,
+		func(ctx context.Context, cfg *RootYAML) (*testClient, error) {
+			c := &testClient{bucket: bucketOf(cfg)}
+			built = append(built, c)
+			return c, nil
+		},
+		func(c *testClient) error {
+			c.closed = true
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r, &built
+}
+
+func TestReloadableSwapsClient(t *testing.T) {
+	ctx := context.Background()
+	w, path := newTestWatcher(t)
+	r, built := newTestReloadable(t, w)
+
+	writeConfig(t, path, "b")
+	if err := w.Reload(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	err := r.Use(func(c *testClient) error {
+		if c.bucket != "b" {
+			t.Errorf("Use got bucket %q, want b", c.bucket)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*built) != 2 || !(*built)[0].closed || (*built)[1].closed {
+		t.Fatalf("expected the first client closed and the second open")
+	}
+}
+
+func TestReloadableCloseUnsubscribes(t *testing.T) {
+	ctx := context.Background()
+	w, path := newTestWatcher(t)
+	r, built := newTestReloadable(t, w)
+
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !(*built)[0].closed {
+		t.Fatal("Close did not close the client")
+	}
+
+	writeConfig(t, path, "b")
+	if err := w.Reload(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(*built) != 1 {
+		t.Fatalf("a reload after Close built %d clients", len(*built)-1)
+	}
+	if err := r.Use(func(*testClient) error { return nil }); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Use after Close = %v, want ErrClosed", err)
+	}
+}
+
+func TestReloadableSwapAfterClose(t *testing.T) {
+	w, _ := newTestWatcher(t)
+	r, _ := newTestReloadable(t, w)
+
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A reload that started before Close finishes its build afterwards.
+	late := &testClient{bucket: "b"}
+	if err := r.swap(&generation[*testClient]{value: late}); err != nil {
+		t.Fatal(err)
+	}
+	if !late.closed {
+		t.Fatal("client built after Close was not closed")
+	}
+	if err := r.Use(func(*testClient) error { return nil }); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Use after Close = %v, want ErrClosed", err)
+	}
+}
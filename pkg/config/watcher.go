@@ -0,0 +1,191 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SectionGRPC names the GRPC target map for Watcher subscriptions.
+const SectionGRPC = "GRPC"
+
+const defaultWatchInterval = 5 * time.Second
+
+// Subscriber is notified with the new configuration after a section changed.
+type Subscriber func(ctx context.Context, cfg *RootYAML) error
+
+type subscription struct {
+	id int
+	fn Subscriber
+}
+
+// Watcher polls the config files, including secret files and profile
+// overlays, reloads and re-validates them, and notifies the subscribers of
+// every section that changed. An invalid reload keeps the previous
+// configuration.
+type Watcher struct {
+	path     string
+	profiles []string
+	interval time.Duration
+
+	// OnError receives reload and subscriber errors. It defaults to printing
+	// them.
+	OnError func(error)
+
+	current  atomic.Pointer[RootYAML]
+	reloadMu sync.Mutex
+
+	mu     sync.Mutex
+	subs   map[string][]subscription
+	nextID int
+	// applied holds, per section, the configuration its subscribers last
+	// applied successfully, so that a failed rebuild is retried on the next
+	// reload.
+	applied map[string]*RootYAML
+	lastErr string
+}
+
+func NewWatcher(path string, interval time.Duration, profiles ...string) (*Watcher, error) {
+	cfg, err := LoadConfig(path, profiles...)
+	if err != nil {
+		return nil, err
+	}
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	w := &Watcher{
+		path:     path,
+		profiles: profiles,
+		interval: interval,
+		OnError: func(err error) {
+			fmt.Printf("config reload failed: %v\n", err)
+		},
+		subs:    make(map[string][]subscription),
+		applied: make(map[string]*RootYAML),
+	}
+	w.current.Store(cfg)
+	for _, name := range sectionNames {
+		w.applied[name] = cfg
+	}
+	w.applied[SectionGRPC] = cfg
+	return w, nil
+}
+
+// Config returns the last valid configuration.
+func (w *Watcher) Config() *RootYAML {
+	return w.current.Load()
+}
+
+// Subscribe registers fn for changes of a section, one of the Section
+// constants. The returned function removes the subscription; a reload that
+// already started may still call fn.
+func (w *Watcher) Subscribe(section string, fn Subscriber) (unsubscribe func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.nextID++
+	id := w.nextID
+	w.subs[section] = append(w.subs[section], subscription{id: id, fn: fn})
+
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		w.subs[section] = slices.DeleteFunc(w.subs[section], func(s subscription) bool {
+			return s.id == id
+		})
+	}
+}
+
+// Run polls for changes until ctx is done.
+func (w *Watcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := w.Reload(ctx); err != nil {
+				w.report(err)
+			}
+		}
+	}
+}
+
+// Reload loads the configuration once and notifies subscribers of the changed
+// sections. A section is only marked as applied when all of its subscribers
+// succeed; otherwise they are all notified again on the next reload.
+func (w *Watcher) Reload(ctx context.Context) error {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	next, err := LoadConfig(w.path, w.profiles...)
+	if err != nil {
+		return err
+	}
+	w.current.Store(next)
+
+	w.mu.Lock()
+	w.lastErr = ""
+	subs := map[string][]subscription{}
+	var changed []string
+	for section, applied := range w.applied {
+		if sectionChanged(section, applied, next) {
+			changed = append(changed, section)
+			subs[section] = slices.Clone(w.subs[section])
+		}
+	}
+	w.mu.Unlock()
+	slices.Sort(changed)
+
+	var errs []error
+	for _, section := range changed {
+		ok := true
+		for _, s := range subs[section] {
+			if err := s.fn(ctx, next); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", section, err))
+				ok = false
+			}
+		}
+		if ok {
+			w.mu.Lock()
+			w.applied[section] = next
+			w.mu.Unlock()
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// report forwards err to OnError unless it repeats the previous error, so an
+// invalid file is reported once rather than on every poll.
+func (w *Watcher) report(err error) {
+	w.mu.Lock()
+	repeated := err.Error() == w.lastErr
+	w.lastErr = err.Error()
+	w.mu.Unlock()
+
+	if !repeated && w.OnError != nil {
+		w.OnError(err)
+	}
+}
+
+func sectionChanged(name string, prev, next *RootYAML) bool {
+	if name == SectionGRPC {
+		return !reflect.DeepEqual(prev.Targets(), next.Targets())
+	}
+	return !sameSection(prev.section(name), next.section(name))
+}
+
+func sameSection(a, b *FactoryConfig) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Driver == b.Driver && reflect.DeepEqual(a.Options, b.Options)
+}
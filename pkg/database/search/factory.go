@@ -2,27 +2,40 @@ package search
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/bexprt/bexgen-client/internal/database/search"
 	"github.com/bexprt/bexgen-client/pkg/config"
 	searchtypes "github.com/bexprt/bexgen-client/pkg/database/search/types"
+	"github.com/bexprt/bexgen-client/pkg/registry"
 )
 
-func NewClient(ctx context.Context, cfg *config.RootYAML) (searchtypes.Index, error) {
-	if cfg.Search == nil {
-		return nil, fmt.Errorf("search config not found")
-	}
-	if cfg.Search.Driver == "" {
-		return nil, fmt.Errorf("search.driver is required")
-	}
+// Constructor builds an Index from the search section.
+type Constructor func(ctx context.Context, cfg *config.FactoryConfig) (searchtypes.Index, error)
+
+var drivers = registry.New[Constructor](config.SectionSearch)
+
+func init() {
+	Register("opensearch", search.NewClientOpenSearch)
+	Register("elasticsearch", search.NewClientElasticSearch)
+	config.RegisterOptions(config.SectionSearch, "opensearch", func() any { return &search.OpenSearchConfig{} })
+	config.RegisterOptions(config.SectionSearch, "elasticsearch", func() any { return &search.ElasticSearchConfig{} })
+}
 
-	switch cfg.Search.Driver {
-	case "opensearch":
-		return search.NewClientOpenSearch(ctx, cfg.Search)
-	case "elasticsearch":
-		return search.NewClientElasticSearch(ctx, cfg.Search)
-	default:
-		return nil, fmt.Errorf("unsupported driver: %s", cfg.Search.Driver)
+// Register makes an Index driver available to NewClient. Drivers with typed
+// options should also call config.RegisterOptions.
+func Register(driver string, constructor Constructor) {
+	drivers.Register(driver, constructor)
+}
+
+// Drivers returns the names of the registered search drivers.
+func Drivers() []string {
+	return drivers.Drivers()
+}
+
+func NewClient(ctx context.Context, cfg *config.RootYAML) (searchtypes.Index, error) {
+	constructor, err := drivers.Lookup(cfg.Search)
+	if err != nil {
+		return nil, err
 	}
+	return constructor(ctx, cfg.Search)
 }
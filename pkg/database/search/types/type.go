@@ -17,6 +17,19 @@ const (
 	Dot       DistanceMetric = "dot"
 )
 
+// VectorElementType is the storage type of indexed vectors. Byte vectors
+// hold int8 values and bit vectors hold 8 packed dimensions per int8 value,
+// both passed as float32 in Document.Vector and VectorSearch queries.
+type VectorElementType string
+
+const (
+	ElementFloat VectorElementType = "float"
+	ElementByte  VectorElementType = "byte"
+	ElementBit   VectorElementType = "bit"
+)
+
+const DefaultDimension = 1024
+
 type SearchFileds string
 
 const (
@@ -41,9 +54,13 @@ type SearchOptions struct {
 }
 
 type IndexOptions struct {
-	Name         string
-	Dimension    int
+	Name string
+	// Dimension is the number of dimensions, also for bit vectors. Defaults
+	// to DefaultDimension.
+	Dimension int
+	// Metric defaults to Cosine. Bit vectors always use hamming distance.
 	Metric       DistanceMetric
+	ElementType  VectorElementType
 	Shards       *string
 	Replicas     *string
 	Metadata     map[string]any
@@ -0,0 +1,214 @@
+package sql
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bexprt/bexgen-client/pkg/database/sql/migrations"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	migrationsTable = "schema_migrations"
+	// migrationLockID is the pg_advisory_lock key serializing migration runs.
+	migrationLockID int64 = 0x62657867656e // "bexgen"
+)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Migrator applies the embedded migrations, recording them in the
+// schema_migrations table. Every run holds a Postgres advisory lock, so
+// services starting concurrently apply each migration once.
+type Migrator struct {
+	conn       *pgx.Conn
+	migrations []Migration
+}
+
+func NewMigrator(conn *pgx.Conn) (*Migrator, error) {
+	ms, err := LoadMigrations(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{conn: conn, migrations: ms}, nil
+}
+
+// LoadMigrations reads <version>_<name>.up.sql and .down.sql pairs from fsys,
+// sorted by version.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, file := range files {
+		base, direction, ok := strings.Cut(strings.TrimSuffix(file, ".sql"), ".")
+		if !ok || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("migration %s: expected <version>_<name>.up.sql or .down.sql", file)
+		}
+		v, name, _ := strings.Cut(base, "_")
+		version, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: invalid version: %w", file, err)
+		}
+
+		body, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		if direction == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	ms := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", m.Version, m.Name)
+		}
+		ms = append(ms, *m)
+	}
+	slices.SortFunc(ms, func(a, b Migration) int { return cmp.Compare(a.Version, b.Version) })
+	return ms, nil
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.locked(ctx, func(applied map[int64]bool) error {
+		for _, mig := range m.migrations {
+			if applied[mig.Version] {
+				continue
+			}
+			err := pgx.BeginFunc(ctx, m.conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, mig.Up); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx,
+					"INSERT INTO "+migrationsTable+" (version, name) VALUES ($1, $2)",
+					mig.Version, mig.Name)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s up failed: %w", mig.Version, mig.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// Down rolls back the last steps applied migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	return m.locked(ctx, func(applied map[int64]bool) error {
+		for i := len(m.migrations) - 1; i >= 0 && steps > 0; i-- {
+			mig := m.migrations[i]
+			if !applied[mig.Version] {
+				continue
+			}
+			if mig.Down == "" {
+				return fmt.Errorf("migration %d_%s has no down file", mig.Version, mig.Name)
+			}
+			err := pgx.BeginFunc(ctx, m.conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, mig.Down); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx, "DELETE FROM "+migrationsTable+" WHERE version = $1", mig.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s down failed: %w", mig.Version, mig.Name, err)
+			}
+			steps--
+		}
+		return nil
+	})
+}
+
+// Force records every migration up to version as applied without running
+// it, for databases created from the schema before migrations existed.
+func (m *Migrator) Force(ctx context.Context, version int64) error {
+	return m.locked(ctx, func(applied map[int64]bool) error {
+		for _, mig := range m.migrations {
+			if mig.Version > version || applied[mig.Version] {
+				continue
+			}
+			_, err := m.conn.Exec(ctx,
+				"INSERT INTO "+migrationsTable+" (version, name) VALUES ($1, $2)",
+				mig.Version, mig.Name)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Version returns the highest applied migration version, or 0.
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return 0, err
+	}
+	var version int64
+	err := m.conn.QueryRow(ctx, "SELECT COALESCE(MAX(version), 0) FROM "+migrationsTable).Scan(&version)
+	return version, err
+}
+
+func (m *Migrator) locked(ctx context.Context, fn func(applied map[int64]bool) error) (err error) {
+	if _, err := m.conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		// Use a fresh context so the lock is released even if ctx was cancelled.
+		if _, uerr := m.conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID); uerr != nil && err == nil {
+			err = fmt.Errorf("failed to release migration lock: %w", uerr)
+		}
+	}()
+
+	if err := m.ensureTable(ctx); err != nil {
+		return err
+	}
+
+	rows, err := m.conn.Query(ctx, "SELECT version FROM "+migrationsTable)
+	if err != nil {
+		return err
+	}
+	versions, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return err
+	}
+
+	applied := make(map[int64]bool, len(versions))
+	for _, v := range versions {
+		applied[v] = true
+	}
+	return fn(applied)
+}
+
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	_, err := m.conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS `+migrationsTable+` (
+  version BIGINT PRIMARY KEY,
+  name TEXT NOT NULL,
+  applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", migrationsTable, err)
+	}
+	return nil
+}
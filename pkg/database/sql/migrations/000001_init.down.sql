@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS metadata;
+DROP TABLE IF EXISTS sites;
+DROP TABLE IF EXISTS subcategories;
+DROP TABLE IF EXISTS categories;
+DROP TABLE IF EXISTS audit_events;
+DROP TABLE IF EXISTS failed_messages;
+DROP TABLE IF EXISTS document_status;
+DROP TABLE IF EXISTS processing_steps;
+DROP TABLE IF EXISTS documents;
+DROP TYPE IF EXISTS retry_state;
+DROP TYPE IF EXISTS processing_state;
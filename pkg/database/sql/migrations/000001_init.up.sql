@@ -26,8 +26,6 @@ CREATE TABLE documents(
   created_at TIMESTAMPTZ DEFAULT now(),
   updated_at TIMESTAMPTZ DEFAULT now()
 );
-CREATE INDEX idx_documents_external_id
-ON documents(external_id);
 -- =========================
 -- PROCESSING STEPS
 -- =========================
@@ -0,0 +1,12 @@
+-- =========================
+-- EMBEDDING CACHE
+-- =========================
+CREATE TABLE embedding_cache(
+  cache_key TEXT PRIMARY KEY,
+  model_id TEXT NOT NULL,
+  input_type TEXT NOT NULL,
+  dimension INT NOT NULL,
+  embedding REAL[] NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX idx_embedding_cache_created_at ON embedding_cache(created_at);
@@ -0,0 +1,15 @@
+-- =========================
+-- AI USAGE
+-- =========================
+CREATE TABLE ai_usage(
+  id BIGSERIAL PRIMARY KEY,
+  document_id TEXT,
+  model_id TEXT NOT NULL,
+  operation TEXT NOT NULL,
+  input_tokens INT NOT NULL DEFAULT 0,
+  output_tokens INT NOT NULL DEFAULT 0,
+  cost_usd DOUBLE PRECISION NOT NULL DEFAULT 0,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX idx_ai_usage_document_id ON ai_usage(document_id);
+CREATE INDEX idx_ai_usage_created_at ON ai_usage(created_at);
@@ -0,0 +1,5 @@
+ALTER TABLE ai_usage
+  DROP COLUMN IF EXISTS prompt_version,
+  DROP COLUMN IF EXISTS prompt_name;
+
+DROP TABLE IF EXISTS prompt_templates;
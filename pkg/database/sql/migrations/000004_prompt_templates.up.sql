@@ -0,0 +1,16 @@
+-- =========================
+-- PROMPT TEMPLATES
+-- =========================
+CREATE TABLE prompt_templates(
+  name TEXT NOT NULL,
+  version TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  required TEXT[] NOT NULL DEFAULT '{}',
+  template TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (name, version)
+);
+
+ALTER TABLE ai_usage
+  ADD COLUMN prompt_name TEXT NOT NULL DEFAULT '',
+  ADD COLUMN prompt_version TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+ALTER TABLE documents
+  ADD COLUMN summary TEXT NOT NULL DEFAULT '';
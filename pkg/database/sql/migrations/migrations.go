@@ -0,0 +1,9 @@
+// Package migrations embeds the versioned schema migrations. Files are named
+// <version>_<name>.up.sql and <version>_<name>.down.sql; sqlc reads the up
+// files as the schema.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -8,6 +8,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -100,6 +101,19 @@ func (ns NullRetryState) Value() (driver.Value, error) {
 	return string(ns.RetryState), nil
 }
 
+type AiUsage struct {
+	ID            int64     `json:"id"`
+	DocumentID    string    `json:"document_id"`
+	ModelID       string    `json:"model_id"`
+	Operation     string    `json:"operation"`
+	InputTokens   int32     `json:"input_tokens"`
+	OutputTokens  int32     `json:"output_tokens"`
+	CostUsd       float64   `json:"cost_usd"`
+	CreatedAt     time.Time `json:"created_at"`
+	PromptName    string    `json:"prompt_name"`
+	PromptVersion string    `json:"prompt_version"`
+}
+
 type AuditEvent struct {
 	ID         uuid.UUID          `json:"id"`
 	ResourceID uuid.UUID          `json:"resource_id"`
@@ -126,6 +140,7 @@ type Document struct {
 	Classification string             `json:"classification"`
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	Summary        string             `json:"summary"`
 }
 
 type DocumentStatus struct {
@@ -136,6 +151,15 @@ type DocumentStatus struct {
 	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
 }
 
+type EmbeddingCache struct {
+	CacheKey  string    `json:"cache_key"`
+	ModelID   string    `json:"model_id"`
+	InputType string    `json:"input_type"`
+	Dimension int32     `json:"dimension"`
+	Embedding []float32 `json:"embedding"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type FailedMessage struct {
 	ID              uuid.UUID          `json:"id"`
 	DocumentID      uuid.UUID          `json:"document_id"`
@@ -170,6 +194,15 @@ type ProcessingStep struct {
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 }
 
+type PromptTemplate struct {
+	Name        string    `json:"name"`
+	Version     string    `json:"version"`
+	Description string    `json:"description"`
+	Required    []string  `json:"required"`
+	Template    string    `json:"template"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
 type Site struct {
 	ID                       int32              `json:"id"`
 	Pk                       string             `json:"pk"`
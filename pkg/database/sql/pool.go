@@ -0,0 +1,201 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/bexprt/bexgen-client/pkg/config"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	pgxvec "github.com/pgvector/pgvector-go/pgx"
+)
+
+const driverPostgres = "postgres"
+
+func init() {
+	config.RegisterOptions(config.SectionSQL, driverPostgres, func() any { return &Options{} })
+}
+
+// Options configures the Sql section. Either DSN or Host and Database must be
+// set; the individual fields override the matching DSN parts.
+type Options struct {
+	DSN      string `yaml:"dsn"`
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	Database string `yaml:"database"`
+	SSLMode  string `yaml:"sslmode"`
+
+	MaxConns          int32         `yaml:"max_conns"`
+	MinConns          int32         `yaml:"min_conns"`
+	MaxConnLifetime   time.Duration `yaml:"max_conn_lifetime"`
+	MaxConnIdleTime   time.Duration `yaml:"max_conn_idle_time"`
+	HealthCheckPeriod time.Duration `yaml:"health_check_period"`
+	StatementTimeout  time.Duration `yaml:"statement_timeout"`
+
+	// Migrate applies pending migrations before the pool is created.
+	Migrate bool `yaml:"migrate"`
+}
+
+func (o *Options) Validate() error {
+	var errs []error
+	if o.DSN == "" && (o.Host == "" || o.Database == "") {
+		errs = append(errs, fmt.Errorf("dsn or host and database are required"))
+	}
+	if o.MaxConns < 0 || o.MinConns < 0 {
+		errs = append(errs, fmt.Errorf("max_conns and min_conns must not be negative"))
+	}
+	if o.MaxConns > 0 && o.MinConns > o.MaxConns {
+		errs = append(errs, fmt.Errorf("min_conns (%d) exceeds max_conns (%d)", o.MinConns, o.MaxConns))
+	}
+	return errors.Join(errs...)
+}
+
+// connString returns the DSN, or a minimal URL when only the individual
+// fields are set. Host, credentials and database are applied after parsing by
+// applyConnOverrides so that they need no escaping.
+func (o *Options) connString() string {
+	dsn := o.DSN
+	if dsn == "" {
+		dsn = "postgres://" + o.Host + "/" + url.PathEscape(o.Database)
+	}
+	if o.SSLMode == "" {
+		return dsn
+	}
+
+	if u, err := url.Parse(dsn); err == nil && u.Scheme != "" {
+		q := u.Query()
+		q.Set("sslmode", o.SSLMode)
+		u.RawQuery = q.Encode()
+		return u.String()
+	}
+	return dsn + " sslmode=" + o.SSLMode
+}
+
+func options(cfg *config.RootYAML) (*Options, error) {
+	if cfg.SQL == nil {
+		return nil, fmt.Errorf("Sql config not found")
+	}
+	if cfg.SQL.Driver != driverPostgres {
+		return nil, fmt.Errorf("unsupported driver: %s", cfg.SQL.Driver)
+	}
+
+	opts := &Options{}
+	if err := cfg.SQL.DecodeOptions(opts); err != nil {
+		return nil, fmt.Errorf("invalid Sql configuration: %w", err)
+	}
+	return opts, nil
+}
+
+func connConfig(opts *Options) (*pgx.ConnConfig, error) {
+	cc, err := pgx.ParseConfig(opts.connString())
+	if err != nil {
+		return nil, fmt.Errorf("invalid Sql connection settings: %w", err)
+	}
+	applyConnOverrides(cc, opts)
+	return cc, nil
+}
+
+// applyConnOverrides sets the individual connection fields on top of the
+// parsed DSN.
+func applyConnOverrides(cc *pgx.ConnConfig, opts *Options) {
+	if opts.Host != "" {
+		cc.Host = opts.Host
+	}
+	if opts.Port > 0 {
+		cc.Port = uint16(opts.Port)
+	}
+	if opts.User != "" {
+		cc.User = opts.User
+	}
+	if opts.Password != "" {
+		cc.Password = opts.Password
+	}
+	if opts.Database != "" {
+		cc.Database = opts.Database
+	}
+	if opts.StatementTimeout > 0 {
+		cc.RuntimeParams["statement_timeout"] = strconv.FormatInt(opts.StatementTimeout.Milliseconds(), 10)
+	}
+}
+
+// Connect opens a single connection from the Sql section, without pgvector
+// types, e.g. for running migrations.
+func Connect(ctx context.Context, cfg *config.RootYAML) (*pgx.Conn, error) {
+	opts, err := options(cfg)
+	if err != nil {
+		return nil, err
+	}
+	cc, err := connConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.ConnectConfig(ctx, cc)
+}
+
+// Migrate applies all pending migrations using the Sql section.
+func Migrate(ctx context.Context, cfg *config.RootYAML) error {
+	conn, err := Connect(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect for migrations: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	m, err := NewMigrator(conn)
+	if err != nil {
+		return err
+	}
+	return m.Up(ctx)
+}
+
+// NewPool builds a connection pool from the Sql section with pgvector types
+// registered on every connection. Pending migrations run first when the
+// migrate option is set.
+func NewPool(ctx context.Context, cfg *config.RootYAML) (*pgxpool.Pool, error) {
+	opts, err := options(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Migrate {
+		// The vector extension is created by the migrations, so they run on a
+		// plain connection before pgvector types are registered.
+		if err := Migrate(ctx, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	pc, err := pgxpool.ParseConfig(opts.connString())
+	if err != nil {
+		return nil, fmt.Errorf("invalid Sql connection settings: %w", err)
+	}
+	applyConnOverrides(pc.ConnConfig, opts)
+
+	if opts.MaxConns > 0 {
+		pc.MaxConns = opts.MaxConns
+	}
+	if opts.MinConns > 0 {
+		pc.MinConns = opts.MinConns
+	}
+	if opts.MaxConnLifetime > 0 {
+		pc.MaxConnLifetime = opts.MaxConnLifetime
+	}
+	if opts.MaxConnIdleTime > 0 {
+		pc.MaxConnIdleTime = opts.MaxConnIdleTime
+	}
+	if opts.HealthCheckPeriod > 0 {
+		pc.HealthCheckPeriod = opts.HealthCheckPeriod
+	}
+	pc.AfterConnect = pgxvec.RegisterTypes
+
+	pool, err := pgxpool.NewWithConfig(ctx, pc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Postgres pool: %w", err)
+	}
+	return pool, nil
+}
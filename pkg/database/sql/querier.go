@@ -6,6 +6,7 @@ package sql
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -37,6 +38,7 @@ type Querier interface {
 	// =========================================
 	CreateSite(ctx context.Context, arg CreateSiteParams) (int32, error)
 	CreateSubcategory(ctx context.Context, arg CreateSubcategoryParams) (Subcategory, error)
+	DeleteCachedEmbeddingsBefore(ctx context.Context, createdAt time.Time) (int64, error)
 	DeleteCategory(ctx context.Context, id uuid.UUID) error
 	DeleteSubcategory(ctx context.Context, id uuid.UUID) error
 	EnsureProcessingStep(ctx context.Context, name string) error
@@ -48,6 +50,10 @@ type Querier interface {
 	GetAuditCursor(ctx context.Context, arg GetAuditCursorParams) ([]AuditEvent, error)
 	GetAuditFiltered(ctx context.Context, arg GetAuditFilteredParams) ([]AuditEvent, error)
 	GetAuditTimeline(ctx context.Context, arg GetAuditTimelineParams) ([]AuditEvent, error)
+	// =====================================
+	// EMBEDDING CACHE
+	// =====================================
+	GetCachedEmbeddings(ctx context.Context, cacheKeys []string) ([]GetCachedEmbeddingsRow, error)
 	GetCategoryByName(ctx context.Context, name string) (Category, error)
 	GetDailyProgress(ctx context.Context, arg GetDailyProgressParams) ([]GetDailyProgressRow, error)
 	GetDocumentByID(ctx context.Context, id uuid.UUID) (Document, error)
@@ -72,12 +78,24 @@ type Querier interface {
 	GetSitesByState(ctx context.Context, state string) ([]GetSitesByStateRow, error)
 	GetSitesByZip(ctx context.Context, zip string) ([]GetSitesByZipRow, error)
 	GetStatusDistribution(ctx context.Context, arg GetStatusDistributionParams) ([]GetStatusDistributionRow, error)
+	GetUsageByDay(ctx context.Context, arg GetUsageByDayParams) ([]GetUsageByDayRow, error)
+	GetUsageByDocument(ctx context.Context, documentID string) ([]GetUsageByDocumentRow, error)
+	GetUsageByModel(ctx context.Context, arg GetUsageByModelParams) ([]GetUsageByModelRow, error)
 	IncrementRetryCount(ctx context.Context, id uuid.UUID) error
 	// =====================================
+	// AI USAGE
+	// =====================================
+	InsertAIUsage(ctx context.Context, arg InsertAIUsageParams) error
+	// =====================================
 	// FAILED MESSAGE STORAGE
 	// =====================================
 	InsertFailedMessage(ctx context.Context, arg InsertFailedMessageParams) (FailedMessage, error)
 	ListCategories(ctx context.Context) ([]Category, error)
+	ListDocumentUsage(ctx context.Context, arg ListDocumentUsageParams) ([]ListDocumentUsageRow, error)
+	// =====================================
+	// PROMPT TEMPLATES
+	// =====================================
+	ListPromptTemplates(ctx context.Context) ([]PromptTemplate, error)
 	ListSubcategories(ctx context.Context) ([]ListSubcategoriesRow, error)
 	ListSubcategoriesByCategory(ctx context.Context, categoryID uuid.UUID) ([]ListSubcategoriesByCategoryRow, error)
 	MarkFailedMessageDeadLetter(ctx context.Context, id uuid.UUID) error
@@ -85,11 +103,13 @@ type Querier interface {
 	// =========================================
 	// VECTOR SIMILARITY SEARCH
 	// =========================================
+	PutCachedEmbedding(ctx context.Context, arg PutCachedEmbeddingParams) error
 	SimilarLandlord(ctx context.Context, arg SimilarLandlordParams) ([]SimilarLandlordRow, error)
 	SimilarLandlordAddress(ctx context.Context, arg SimilarLandlordAddressParams) ([]SimilarLandlordAddressRow, error)
 	SimilarSiteAddress(ctx context.Context, arg SimilarSiteAddressParams) ([]SimilarSiteAddressRow, error)
 	UpdateDocumentClassification(ctx context.Context, arg UpdateDocumentClassificationParams) error
 	UpdateDocumentStatus(ctx context.Context, arg UpdateDocumentStatusParams) error
+	UpdateDocumentSummary(ctx context.Context, arg UpdateDocumentSummaryParams) error
 	UpdateLandlordAddressEmbedding(ctx context.Context, arg UpdateLandlordAddressEmbeddingParams) error
 	// =========================================
 	// UPDATE EMBEDDINGS
@@ -106,6 +126,7 @@ type Querier interface {
 	// =====================================
 	// Upsert current step status
 	UpsertDocumentStatus(ctx context.Context, arg UpsertDocumentStatusParams) error
+	UpsertPromptTemplate(ctx context.Context, arg UpsertPromptTemplateParams) error
 }
 
 var _ Querier = (*Queries)(nil)
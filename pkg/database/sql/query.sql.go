@@ -219,7 +219,7 @@ INSERT INTO documents (
     classification
 )
 VALUES ($1, $2, $3, $4)
-RETURNING id, filename, filepath, classification, created_at, updated_at
+RETURNING id, filename, filepath, classification, created_at, updated_at, summary
 `
 
 type CreateDocumentParams struct {
@@ -247,6 +247,7 @@ func (q *Queries) CreateDocument(ctx context.Context, arg CreateDocumentParams)
 		&i.Classification,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Summary,
 	)
 	return i, err
 }
@@ -525,6 +526,19 @@ func (q *Queries) CreateSubcategory(ctx context.Context, arg CreateSubcategoryPa
 	return i, err
 }
 
+const deleteCachedEmbeddingsBefore = `-- name: DeleteCachedEmbeddingsBefore :execrows
+DELETE FROM embedding_cache
+WHERE created_at < $1
+`
+
+func (q *Queries) DeleteCachedEmbeddingsBefore(ctx context.Context, createdAt time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCachedEmbeddingsBefore, createdAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const deleteCategory = `-- name: DeleteCategory :exec
 DELETE FROM categories
 WHERE id = $1
@@ -930,6 +944,41 @@ func (q *Queries) GetAuditTimeline(ctx context.Context, arg GetAuditTimelinePara
 	return items, nil
 }
 
+const getCachedEmbeddings = `-- name: GetCachedEmbeddings :many
+
+SELECT cache_key, embedding
+FROM embedding_cache
+WHERE cache_key = ANY($1::text[])
+`
+
+type GetCachedEmbeddingsRow struct {
+	CacheKey  string    `json:"cache_key"`
+	Embedding []float32 `json:"embedding"`
+}
+
+// =====================================
+// EMBEDDING CACHE
+// =====================================
+func (q *Queries) GetCachedEmbeddings(ctx context.Context, cacheKeys []string) ([]GetCachedEmbeddingsRow, error) {
+	rows, err := q.db.Query(ctx, getCachedEmbeddings, cacheKeys)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCachedEmbeddingsRow
+	for rows.Next() {
+		var i GetCachedEmbeddingsRow
+		if err := rows.Scan(&i.CacheKey, &i.Embedding); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getCategoryByName = `-- name: GetCategoryByName :one
 SELECT id, name, embedding, description, created_at, updated_at
 FROM categories
@@ -994,7 +1043,7 @@ func (q *Queries) GetDailyProgress(ctx context.Context, arg GetDailyProgressPara
 }
 
 const getDocumentByID = `-- name: GetDocumentByID :one
-SELECT id, filename, filepath, classification, created_at, updated_at
+SELECT id, filename, filepath, classification, created_at, updated_at, summary
 FROM documents
 WHERE id = $1
 `
@@ -1009,6 +1058,7 @@ func (q *Queries) GetDocumentByID(ctx context.Context, id uuid.UUID) (Document,
 		&i.Classification,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Summary,
 	)
 	return i, err
 }
@@ -1820,6 +1870,163 @@ func (q *Queries) GetStatusDistribution(ctx context.Context, arg GetStatusDistri
 	return items, nil
 }
 
+const getUsageByDay = `-- name: GetUsageByDay :many
+SELECT
+    (created_at AT TIME ZONE 'UTC')::date AS day,
+    model_id,
+    COUNT(*) AS calls,
+    COALESCE(SUM(input_tokens), 0)::bigint AS input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS output_tokens,
+    COALESCE(SUM(cost_usd), 0)::float8 AS cost_usd
+FROM ai_usage
+WHERE created_at BETWEEN $1 AND $2
+GROUP BY day, model_id
+ORDER BY day, model_id
+`
+
+type GetUsageByDayParams struct {
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
+
+type GetUsageByDayRow struct {
+	Day          time.Time `json:"day"`
+	ModelID      string    `json:"model_id"`
+	Calls        int64     `json:"calls"`
+	InputTokens  int64     `json:"input_tokens"`
+	OutputTokens int64     `json:"output_tokens"`
+	CostUsd      float64   `json:"cost_usd"`
+}
+
+func (q *Queries) GetUsageByDay(ctx context.Context, arg GetUsageByDayParams) ([]GetUsageByDayRow, error) {
+	rows, err := q.db.Query(ctx, getUsageByDay, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUsageByDayRow
+	for rows.Next() {
+		var i GetUsageByDayRow
+		if err := rows.Scan(
+			&i.Day,
+			&i.ModelID,
+			&i.Calls,
+			&i.InputTokens,
+			&i.OutputTokens,
+			&i.CostUsd,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUsageByDocument = `-- name: GetUsageByDocument :many
+SELECT
+    model_id,
+    operation,
+    COUNT(*) AS calls,
+    COALESCE(SUM(input_tokens), 0)::bigint AS input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS output_tokens,
+    COALESCE(SUM(cost_usd), 0)::float8 AS cost_usd
+FROM ai_usage
+WHERE document_id = $1::text
+GROUP BY model_id, operation
+ORDER BY model_id, operation
+`
+
+type GetUsageByDocumentRow struct {
+	ModelID      string  `json:"model_id"`
+	Operation    string  `json:"operation"`
+	Calls        int64   `json:"calls"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	CostUsd      float64 `json:"cost_usd"`
+}
+
+func (q *Queries) GetUsageByDocument(ctx context.Context, documentID string) ([]GetUsageByDocumentRow, error) {
+	rows, err := q.db.Query(ctx, getUsageByDocument, documentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUsageByDocumentRow
+	for rows.Next() {
+		var i GetUsageByDocumentRow
+		if err := rows.Scan(
+			&i.ModelID,
+			&i.Operation,
+			&i.Calls,
+			&i.InputTokens,
+			&i.OutputTokens,
+			&i.CostUsd,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUsageByModel = `-- name: GetUsageByModel :many
+SELECT
+    model_id,
+    COUNT(*) AS calls,
+    COALESCE(SUM(input_tokens), 0)::bigint AS input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS output_tokens,
+    COALESCE(SUM(cost_usd), 0)::float8 AS cost_usd
+FROM ai_usage
+WHERE created_at BETWEEN $1 AND $2
+GROUP BY model_id
+ORDER BY cost_usd DESC
+`
+
+type GetUsageByModelParams struct {
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
+
+type GetUsageByModelRow struct {
+	ModelID      string  `json:"model_id"`
+	Calls        int64   `json:"calls"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	CostUsd      float64 `json:"cost_usd"`
+}
+
+func (q *Queries) GetUsageByModel(ctx context.Context, arg GetUsageByModelParams) ([]GetUsageByModelRow, error) {
+	rows, err := q.db.Query(ctx, getUsageByModel, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUsageByModelRow
+	for rows.Next() {
+		var i GetUsageByModelRow
+		if err := rows.Scan(
+			&i.ModelID,
+			&i.Calls,
+			&i.InputTokens,
+			&i.OutputTokens,
+			&i.CostUsd,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const incrementRetryCount = `-- name: IncrementRetryCount :exec
 UPDATE failed_messages
 SET
@@ -1833,6 +2040,62 @@ func (q *Queries) IncrementRetryCount(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const insertAIUsage = `-- name: InsertAIUsage :exec
+
+INSERT INTO ai_usage (
+    document_id,
+    model_id,
+    operation,
+    input_tokens,
+    output_tokens,
+    cost_usd,
+    created_at,
+    prompt_name,
+    prompt_version
+)
+VALUES (
+    NULLIF($1::text, ''),
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    NULLIF($8::text, ''),
+    NULLIF($9::text, '')
+)
+`
+
+type InsertAIUsageParams struct {
+	DocumentID    string    `json:"document_id"`
+	ModelID       string    `json:"model_id"`
+	Operation     string    `json:"operation"`
+	InputTokens   int32     `json:"input_tokens"`
+	OutputTokens  int32     `json:"output_tokens"`
+	CostUsd       float64   `json:"cost_usd"`
+	CreatedAt     time.Time `json:"created_at"`
+	PromptName    string    `json:"prompt_name"`
+	PromptVersion string    `json:"prompt_version"`
+}
+
+// =====================================
+// AI USAGE
+// =====================================
+func (q *Queries) InsertAIUsage(ctx context.Context, arg InsertAIUsageParams) error {
+	_, err := q.db.Exec(ctx, insertAIUsage,
+		arg.DocumentID,
+		arg.ModelID,
+		arg.Operation,
+		arg.InputTokens,
+		arg.OutputTokens,
+		arg.CostUsd,
+		arg.CreatedAt,
+		arg.PromptName,
+		arg.PromptVersion,
+	)
+	return err
+}
+
 const insertFailedMessage = `-- name: InsertFailedMessage :one
 
 INSERT INTO failed_messages (
@@ -1920,6 +2183,104 @@ func (q *Queries) ListCategories(ctx context.Context) ([]Category, error) {
 	return items, nil
 }
 
+const listDocumentUsage = `-- name: ListDocumentUsage :many
+SELECT
+    document_id,
+    COUNT(*) AS calls,
+    COALESCE(SUM(input_tokens), 0)::bigint AS input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS output_tokens,
+    COALESCE(SUM(cost_usd), 0)::float8 AS cost_usd
+FROM ai_usage
+WHERE document_id IS NOT NULL
+  AND created_at BETWEEN $1 AND $2
+GROUP BY document_id
+ORDER BY cost_usd DESC, document_id
+LIMIT $3 OFFSET $4
+`
+
+type ListDocumentUsageParams struct {
+	StartDate  time.Time `json:"start_date"`
+	EndDate    time.Time `json:"end_date"`
+	PageLimit  int32     `json:"page_limit"`
+	PageOffset int32     `json:"page_offset"`
+}
+
+type ListDocumentUsageRow struct {
+	DocumentID   string  `json:"document_id"`
+	Calls        int64   `json:"calls"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	CostUsd      float64 `json:"cost_usd"`
+}
+
+func (q *Queries) ListDocumentUsage(ctx context.Context, arg ListDocumentUsageParams) ([]ListDocumentUsageRow, error) {
+	rows, err := q.db.Query(ctx, listDocumentUsage,
+		arg.StartDate,
+		arg.EndDate,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDocumentUsageRow
+	for rows.Next() {
+		var i ListDocumentUsageRow
+		if err := rows.Scan(
+			&i.DocumentID,
+			&i.Calls,
+			&i.InputTokens,
+			&i.OutputTokens,
+			&i.CostUsd,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPromptTemplates = `-- name: ListPromptTemplates :many
+
+SELECT name, version, description, required, template, created_at
+FROM prompt_templates
+ORDER BY name, version
+`
+
+// =====================================
+// PROMPT TEMPLATES
+// =====================================
+func (q *Queries) ListPromptTemplates(ctx context.Context) ([]PromptTemplate, error) {
+	rows, err := q.db.Query(ctx, listPromptTemplates)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PromptTemplate
+	for rows.Next() {
+		var i PromptTemplate
+		if err := rows.Scan(
+			&i.Name,
+			&i.Version,
+			&i.Description,
+			&i.Required,
+			&i.Template,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listSubcategories = `-- name: ListSubcategories :many
 SELECT
     s.id,
@@ -2043,6 +2404,37 @@ func (q *Queries) MarkFailedMessageRetried(ctx context.Context, id uuid.UUID) er
 	return err
 }
 
+const putCachedEmbedding = `-- name: PutCachedEmbedding :exec
+INSERT INTO embedding_cache (
+    cache_key,
+    model_id,
+    input_type,
+    dimension,
+    embedding
+)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (cache_key) DO NOTHING
+`
+
+type PutCachedEmbeddingParams struct {
+	CacheKey  string    `json:"cache_key"`
+	ModelID   string    `json:"model_id"`
+	InputType string    `json:"input_type"`
+	Dimension int32     `json:"dimension"`
+	Embedding []float32 `json:"embedding"`
+}
+
+func (q *Queries) PutCachedEmbedding(ctx context.Context, arg PutCachedEmbeddingParams) error {
+	_, err := q.db.Exec(ctx, putCachedEmbedding,
+		arg.CacheKey,
+		arg.ModelID,
+		arg.InputType,
+		arg.Dimension,
+		arg.Embedding,
+	)
+	return err
+}
+
 const similarLandlord = `-- name: SimilarLandlord :many
 
 SELECT
@@ -2291,6 +2683,23 @@ func (q *Queries) UpdateDocumentStatus(ctx context.Context, arg UpdateDocumentSt
 	return err
 }
 
+const updateDocumentSummary = `-- name: UpdateDocumentSummary :exec
+UPDATE documents
+SET summary = $2,
+    updated_at = now()
+WHERE id = $1
+`
+
+type UpdateDocumentSummaryParams struct {
+	ID      uuid.UUID `json:"id"`
+	Summary string    `json:"summary"`
+}
+
+func (q *Queries) UpdateDocumentSummary(ctx context.Context, arg UpdateDocumentSummaryParams) error {
+	_, err := q.db.Exec(ctx, updateDocumentSummary, arg.ID, arg.Summary)
+	return err
+}
+
 const updateLandlordAddressEmbedding = `-- name: UpdateLandlordAddressEmbedding :exec
 UPDATE sites
 SET embedding_landlord_address = $2
@@ -2577,3 +2986,37 @@ func (q *Queries) UpsertDocumentStatus(ctx context.Context, arg UpsertDocumentSt
 	)
 	return err
 }
+
+const upsertPromptTemplate = `-- name: UpsertPromptTemplate :exec
+INSERT INTO prompt_templates (
+    name,
+    version,
+    description,
+    required,
+    template
+)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (name, version) DO UPDATE
+SET description = EXCLUDED.description,
+    required = EXCLUDED.required,
+    template = EXCLUDED.template
+`
+
+type UpsertPromptTemplateParams struct {
+	Name        string   `json:"name"`
+	Version     string   `json:"version"`
+	Description string   `json:"description"`
+	Required    []string `json:"required"`
+	Template    string   `json:"template"`
+}
+
+func (q *Queries) UpsertPromptTemplate(ctx context.Context, arg UpsertPromptTemplateParams) error {
+	_, err := q.db.Exec(ctx, upsertPromptTemplate,
+		arg.Name,
+		arg.Version,
+		arg.Description,
+		arg.Required,
+		arg.Template,
+	)
+	return err
+}
@@ -0,0 +1,145 @@
+package messaging
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/bexprt/bexgen-client/pkg/messaging/types"
+	"github.com/bexprt/bexgen-client/pkg/topics"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func untyped[T proto.Message](topic topics.Topic[T]) topics.Topic[proto.Message] {
+	return topics.Topic[proto.Message]{
+		Name: topic.Name,
+		New:  func() proto.Message { return topic.New() },
+	}
+}
+
+// closer stops the goroutine of an adapter once on Close.
+type closer struct {
+	once sync.Once
+	done chan struct{}
+}
+
+func newCloser() closer {
+	return closer{done: make(chan struct{})}
+}
+
+func (c *closer) close() {
+	c.once.Do(func() { close(c.done) })
+}
+
+type typedPublisher[T proto.Message] struct {
+	inner types.Publisher[proto.Message]
+	closer
+}
+
+func (p *typedPublisher[T]) Open() (chan<- *types.Message[T], error) {
+	out, err := p.inner.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	in := make(chan *types.Message[T], cap(out))
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-p.done:
+				return
+			case m, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case out <- &types.Message[proto.Message]{
+					Key:     m.Key,
+					Value:   m.Value,
+					Headers: m.Headers,
+					Ack:     m.Ack,
+					Nack:    m.Nack,
+				}:
+				case <-p.done:
+					return
+				}
+			}
+		}
+	}()
+
+	return in, nil
+}
+
+func (p *typedPublisher[T]) Close() error {
+	p.close()
+	return p.inner.Close()
+}
+
+type typedConsumer[T proto.Message] struct {
+	inner types.Consumer[proto.Message]
+	closer
+}
+
+func (c *typedConsumer[T]) Open() (<-chan *types.Message[T], error) {
+	in, err := c.inner.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan *types.Message[T], cap(in))
+	go func() {
+		defer close(out)
+		for m := range in {
+			value, ok := m.Value.(T)
+			if !ok {
+				// The message can never be converted, so it is rejected
+				// rather than left in flight.
+				if err := reject(m, fmt.Errorf("unexpected message type %T", m.Value)); err != nil {
+					c.reportError(fmt.Errorf("failed to reject message: %w", err))
+				}
+				continue
+			}
+
+			select {
+			case out <- &types.Message[T]{
+				Key:     m.Key,
+				Value:   value,
+				Headers: m.Headers,
+				Ack:     m.Ack,
+				Nack:    m.Nack,
+			}:
+			case <-c.done:
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func (c *typedConsumer[T]) Close() error {
+	c.close()
+	return c.inner.Close()
+}
+
+// reportError hands err to the OnError hook of the driver consumer, or
+// prints it when the driver has none.
+func (c *typedConsumer[T]) reportError(err error) {
+	if r, ok := c.inner.(types.ErrorReporter); ok {
+		r.ReportError(err)
+		return
+	}
+	fmt.Printf("%v\n", err)
+}
+
+// reject nacks m, or acks it for drivers that don't support Nack.
+func reject(m *types.Message[proto.Message], err error) error {
+	if m.Nack != nil {
+		return m.Nack(err)
+	}
+	if m.Ack != nil {
+		return m.Ack()
+	}
+	return nil
+}
@@ -2,48 +2,69 @@ package messaging
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/bexprt/bexgen-client/internal/messaging/kafka"
 	"github.com/bexprt/bexgen-client/pkg/config"
 	"github.com/bexprt/bexgen-client/pkg/messaging/types"
+	"github.com/bexprt/bexgen-client/pkg/registry"
 	"github.com/bexprt/bexgen-client/pkg/topics"
 
 	"google.golang.org/protobuf/proto"
 )
 
-func check(cfg *config.RootYAML) error {
-	if cfg.Storage == nil {
-		return fmt.Errorf("messaging config not found")
-	}
-	if cfg.Storage.Driver == "" {
-		return fmt.Errorf("messaging.driver is required")
-	}
-	return nil
+// Driver builds publishers and consumers over proto.Message. NewPublisher and
+// NewConsumer adapt them to the message type of the requested topic.
+type Driver struct {
+	NewPublisher func(ctx context.Context, cfg *config.FactoryConfig, topic topics.Topic[proto.Message]) (types.Publisher[proto.Message], error)
+	NewConsumer  func(ctx context.Context, cfg *config.FactoryConfig, topic topics.Topic[proto.Message]) (types.Consumer[proto.Message], error)
+}
+
+var drivers = registry.New[Driver](config.SectionMessaging)
+
+func init() {
+	Register("kafka", Driver{
+		NewPublisher: func(ctx context.Context, cfg *config.FactoryConfig, topic topics.Topic[proto.Message]) (types.Publisher[proto.Message], error) {
+			return kafka.NewPublisher(ctx, cfg, topic)
+		},
+		NewConsumer: func(ctx context.Context, cfg *config.FactoryConfig, topic topics.Topic[proto.Message]) (types.Consumer[proto.Message], error) {
+			return kafka.NewConsumer(ctx, cfg, topic)
+		},
+	})
+	config.RegisterOptions(config.SectionMessaging, "kafka", func() any { return &kafka.Config{} })
+}
+
+// Register makes a messaging driver available to NewPublisher and
+// NewConsumer. Drivers with typed options should also call
+// config.RegisterOptions.
+func Register(driver string, d Driver) {
+	drivers.Register(driver, d)
+}
+
+// Drivers returns the names of the registered messaging drivers.
+func Drivers() []string {
+	return drivers.Drivers()
 }
 
 func NewPublisher[T proto.Message](ctx context.Context, cfg *config.RootYAML, topic topics.Topic[T]) (types.Publisher[T], error) {
-	err := check(cfg)
+	d, err := drivers.Lookup(cfg.Messaging)
 	if err != nil {
 		return nil, err
 	}
-	switch cfg.Messaging.Driver {
-	case "kafka":
-		return kafka.NewPublisher[T](ctx, cfg.Messaging, topic)
-	default:
-		return nil, fmt.Errorf("unsupported driver: %s", cfg.Messaging.Driver)
+	pub, err := d.NewPublisher(ctx, cfg.Messaging, untyped(topic))
+	if err != nil {
+		return nil, err
 	}
+	return &typedPublisher[T]{inner: pub, closer: newCloser()}, nil
 }
 
 func NewConsumer[T proto.Message](ctx context.Context, cfg *config.RootYAML, topic topics.Topic[T]) (types.Consumer[T], error) {
-	err := check(cfg)
+	d, err := drivers.Lookup(cfg.Messaging)
 	if err != nil {
 		return nil, err
 	}
-	switch cfg.Messaging.Driver {
-	case "kafka":
-		return kafka.NewConsumer[T](ctx, cfg.Messaging, topic)
-	default:
-		return nil, fmt.Errorf("unsupported driver: %s", cfg.Messaging.Driver)
+	cons, err := d.NewConsumer(ctx, cfg.Messaging, untyped(topic))
+	if err != nil {
+		return nil, err
 	}
+	return &typedConsumer[T]{inner: cons, closer: newCloser()}, nil
 }
@@ -9,6 +9,9 @@ type Message[T proto.Message] struct {
 	Value   T
 	Headers map[string]string
 	Ack     func() error
+	// Nack rejects a message that can't be processed. Drivers without
+	// redelivery report err and skip the message. May be nil.
+	Nack func(err error) error
 }
 
 type Consumer[T proto.Message] interface {
@@ -16,6 +19,12 @@ type Consumer[T proto.Message] interface {
 	Close() error
 }
 
+// ErrorReporter is implemented by consumers that report asynchronous
+// errors, such as failed Nacks, to a hook of their own.
+type ErrorReporter interface {
+	ReportError(err error)
+}
+
 type Publisher[T proto.Message] interface {
 	Open() (chan<- *Message[T], error)
 	Close() error
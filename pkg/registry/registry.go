@@ -0,0 +1,73 @@
+package registry
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/bexprt/bexgen-client/pkg/config"
+)
+
+// Registry maps driver names to constructors of type F for one config
+// section.
+type Registry[F any] struct {
+	section string
+
+	mu      sync.RWMutex
+	drivers map[string]F
+}
+
+func New[F any](section string) *Registry[F] {
+	return &Registry[F]{
+		section: section,
+		drivers: make(map[string]F),
+	}
+}
+
+// Register makes a driver available under the given name. It panics if the
+// name is empty or already registered.
+func (r *Registry[F]) Register(driver string, constructor F) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if driver == "" {
+		panic(fmt.Sprintf("%s: driver name is empty", r.section))
+	}
+	if _, dup := r.drivers[driver]; dup {
+		panic(fmt.Sprintf("%s: driver %q registered twice", r.section, driver))
+	}
+	r.drivers[driver] = constructor
+}
+
+// Lookup returns the constructor for the driver selected in cfg.
+func (r *Registry[F]) Lookup(cfg *config.FactoryConfig) (F, error) {
+	var zero F
+	if cfg == nil {
+		return zero, fmt.Errorf("%s config not found", r.section)
+	}
+	if cfg.Driver == "" {
+		return zero, fmt.Errorf("%s.driver is required", r.section)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	constructor, ok := r.drivers[cfg.Driver]
+	if !ok {
+		return zero, fmt.Errorf("unsupported driver: %s", cfg.Driver)
+	}
+	return constructor, nil
+}
+
+// Drivers returns the sorted names of the registered drivers.
+func (r *Registry[F]) Drivers() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.drivers))
+	for name := range r.drivers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
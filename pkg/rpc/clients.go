@@ -0,0 +1,282 @@
+package rpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+
+	addressv1 "github.com/bexprt/bexgen-client/pb/address/v1"
+	classificationv1 "github.com/bexprt/bexgen-client/pb/classification/v1"
+	filev1 "github.com/bexprt/bexgen-client/pb/file/v1"
+	"github.com/bexprt/bexgen-client/pkg/config"
+)
+
+// Keys of the GRPC config map.
+const (
+	ServiceOCR        = "ocr"
+	ServiceClassifier = "classifier"
+	ServiceExtractor  = "extractor"
+	ServiceValidation = "validation"
+)
+
+var serviceNames = map[string]string{
+	ServiceOCR:        filev1.OCRService_ServiceDesc.ServiceName,
+	ServiceClassifier: classificationv1.DocumentClassifierService_ServiceDesc.ServiceName,
+	ServiceExtractor:  addressv1.DocumentExtractorService_ServiceDesc.ServiceName,
+	ServiceValidation: addressv1.ValidationService_ServiceDesc.ServiceName,
+}
+
+// Clients holds the typed clients of the services configured in the GRPC
+// map; services that are not configured are nil. Services sharing an address
+// and dial settings share one connection.
+type Clients struct {
+	OCR        filev1.OCRServiceClient
+	Classifier classificationv1.DocumentClassifierServiceClient
+	Extractor  addressv1.DocumentExtractorServiceClient
+	Validation addressv1.ValidationServiceClient
+
+	conns []*grpc.ClientConn
+}
+
+func NewClients(cfg *config.RootYAML) (*Clients, error) {
+	targets := cfg.Targets()
+	for key := range targets {
+		if _, ok := serviceNames[key]; !ok {
+			return nil, fmt.Errorf("unknown gRPC service %q", key)
+		}
+	}
+
+	keys := make([]string, 0, len(targets))
+	for key := range targets {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	// Group services by dial settings, so each group gets one connection whose
+	// service config covers all of its services.
+	type group struct {
+		target   config.GRPCTarget
+		services []string
+	}
+	var groups []*group
+	byDial := map[string]*group{}
+	for _, key := range keys {
+		target := targets[key]
+		dk, err := dialKey(target)
+		if err != nil {
+			return nil, err
+		}
+		g, ok := byDial[dk]
+		if !ok {
+			g = &group{target: target}
+			byDial[dk] = g
+			groups = append(groups, g)
+		}
+		g.services = append(g.services, key)
+	}
+
+	c := &Clients{}
+	for _, g := range groups {
+		conn, err := dial(g.target, g.services, targets)
+		if err != nil {
+			c.Close()
+			return nil, fmt.Errorf("gRPC %s: %w", g.target.Address, err)
+		}
+		c.conns = append(c.conns, conn)
+
+		for _, key := range g.services {
+			switch key {
+			case ServiceOCR:
+				c.OCR = filev1.NewOCRServiceClient(conn)
+			case ServiceClassifier:
+				c.Classifier = classificationv1.NewDocumentClassifierServiceClient(conn)
+			case ServiceExtractor:
+				c.Extractor = addressv1.NewDocumentExtractorServiceClient(conn)
+			case ServiceValidation:
+				c.Validation = addressv1.NewValidationServiceClient(conn)
+			}
+		}
+	}
+
+	return c, nil
+}
+
+// Close closes every connection.
+func (c *Clients) Close() error {
+	var errs []error
+	for _, conn := range c.conns {
+		if err := conn.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	c.conns = nil
+	return errors.Join(errs...)
+}
+
+// dialKey identifies the connection-level settings of a target.
+func dialKey(t config.GRPCTarget) (string, error) {
+	b, err := json.Marshal(struct {
+		Address   string
+		TLS       any
+		Keepalive any
+	}{t.Address, t.TLS, t.Keepalive})
+	return string(b), err
+}
+
+func dial(t config.GRPCTarget, services []string, targets map[string]config.GRPCTarget) (*grpc.ClientConn, error) {
+	creds, err := transportCredentials(t)
+	if err != nil {
+		return nil, err
+	}
+
+	sc, err := serviceConfig(services, targets)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultServiceConfig(sc),
+	}
+	if t.Keepalive.Time > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                t.Keepalive.Time,
+			Timeout:             t.Keepalive.Timeout,
+			PermitWithoutStream: t.Keepalive.PermitWithoutStream,
+		}))
+	}
+
+	return grpc.NewClient(t.Address, opts...)
+}
+
+func transportCredentials(t config.GRPCTarget) (credentials.TransportCredentials, error) {
+	if !t.TLS.Enabled {
+		return insecure.NewCredentials(), nil
+	}
+
+	tc := &tls.Config{
+		ServerName:         t.TLS.ServerName,
+		InsecureSkipVerify: t.TLS.InsecureSkipVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	if t.TLS.CAFile != "" {
+		pem, err := os.ReadFile(t.TLS.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", t.TLS.CAFile)
+		}
+		tc.RootCAs = pool
+	}
+
+	if t.TLS.CertFile != "" || t.TLS.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.TLS.CertFile, t.TLS.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tc.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(tc), nil
+}
+
+type methodName struct {
+	Service string `json:"service"`
+	Method  string `json:"method,omitempty"`
+}
+
+type retryPolicy struct {
+	MaxAttempts          int      `json:"maxAttempts"`
+	InitialBackoff       string   `json:"initialBackoff"`
+	MaxBackoff           string   `json:"maxBackoff"`
+	BackoffMultiplier    float64  `json:"backoffMultiplier"`
+	RetryableStatusCodes []string `json:"retryableStatusCodes"`
+}
+
+type methodConfig struct {
+	Name        []methodName `json:"name"`
+	Timeout     string       `json:"timeout,omitempty"`
+	RetryPolicy *retryPolicy `json:"retryPolicy,omitempty"`
+}
+
+// serviceConfig renders the default deadlines and retry policies of services
+// as a gRPC service config.
+func serviceConfig(services []string, targets map[string]config.GRPCTarget) (string, error) {
+	var methods []methodConfig
+	for _, key := range services {
+		t := targets[key]
+		service := serviceNames[key]
+		policy := retry(t)
+
+		methods = append(methods, methodConfig{
+			Name:        []methodName{{Service: service}},
+			Timeout:     seconds(t.Timeout),
+			RetryPolicy: policy,
+		})
+
+		names := make([]string, 0, len(t.MethodTimeouts))
+		for name := range t.MethodTimeouts {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			methods = append(methods, methodConfig{
+				Name:        []methodName{{Service: service, Method: name}},
+				Timeout:     seconds(t.MethodTimeouts[name]),
+				RetryPolicy: policy,
+			})
+		}
+	}
+
+	b, err := json.Marshal(map[string]any{"methodConfig": methods})
+	return string(b), err
+}
+
+func retry(t config.GRPCTarget) *retryPolicy {
+	r := t.Retry
+	if r.MaxAttempts < 2 {
+		return nil
+	}
+
+	p := &retryPolicy{
+		MaxAttempts:          r.MaxAttempts,
+		InitialBackoff:       seconds(r.InitialBackoff),
+		MaxBackoff:           seconds(r.MaxBackoff),
+		BackoffMultiplier:    r.BackoffMultiplier,
+		RetryableStatusCodes: r.RetryableStatusCodes,
+	}
+	if p.InitialBackoff == "" {
+		p.InitialBackoff = "0.1s"
+	}
+	if p.MaxBackoff == "" {
+		p.MaxBackoff = "5s"
+	}
+	if p.BackoffMultiplier <= 0 {
+		p.BackoffMultiplier = 2
+	}
+	if len(p.RetryableStatusCodes) == 0 {
+		p.RetryableStatusCodes = []string{"UNAVAILABLE"}
+	}
+	return p
+}
+
+func seconds(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
@@ -2,25 +2,38 @@ package storage
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/bexprt/bexgen-client/internal/storage/s3"
 	"github.com/bexprt/bexgen-client/pkg/config"
+	"github.com/bexprt/bexgen-client/pkg/registry"
 	"github.com/bexprt/bexgen-client/pkg/storage/types"
 )
 
-func NewObjectStorage(ctx context.Context, cfg *config.RootYAML) (types.ObjectStorage, error) {
-	if cfg.Storage == nil {
-		return nil, fmt.Errorf("storage config not found")
-	}
-	if cfg.Storage.Driver == "" {
-		return nil, fmt.Errorf("storage.driver is required")
-	}
+// Constructor builds an ObjectStorage from the storage section.
+type Constructor func(ctx context.Context, cfg *config.FactoryConfig) (types.ObjectStorage, error)
+
+var drivers = registry.New[Constructor](config.SectionStorage)
+
+func init() {
+	Register("s3", s3.NewClient)
+	config.RegisterOptions(config.SectionStorage, "s3", func() any { return &s3.S3Config{} })
+}
 
-	switch cfg.Storage.Driver {
-	case "s3":
-		return s3.NewClient(ctx, cfg.Storage)
-	default:
-		return nil, fmt.Errorf("unsupported driver: %s", cfg.Storage.Driver)
+// Register makes an ObjectStorage driver available to NewObjectStorage. Drivers
+// with typed options should also call config.RegisterOptions.
+func Register(driver string, constructor Constructor) {
+	drivers.Register(driver, constructor)
+}
+
+// Drivers returns the names of the registered storage drivers.
+func Drivers() []string {
+	return drivers.Drivers()
+}
+
+func NewObjectStorage(ctx context.Context, cfg *config.RootYAML) (types.ObjectStorage, error) {
+	constructor, err := drivers.Lookup(cfg.Storage)
+	if err != nil {
+		return nil, err
 	}
+	return constructor(ctx, cfg.Storage)
 }
@@ -7,6 +7,7 @@ import (
 	filev1 "github.com/bexprt/bexgen-client/pb/file/v1"
 
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 type Topic[T proto.Message] struct {
@@ -39,4 +40,11 @@ var (
 		Name: "document.addresses.extracted",
 		New:  func() *addressv1.ExtractFieldsResponse { return &addressv1.ExtractFieldsResponse{} },
 	}
+
+	// DocumentSummarized carries the document id, summary, chunk count and
+	// prompt version until the event has its own message in bexgen-proto.
+	DocumentSummarized = Topic[*structpb.Struct]{
+		Name: "document.summarized",
+		New:  func() *structpb.Struct { return &structpb.Struct{} },
+	}
 )
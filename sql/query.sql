@@ -42,6 +42,12 @@ UPDATE documents
 SET classification = $2
 WHERE id = $1;
 
+-- name: UpdateDocumentSummary :exec
+UPDATE documents
+SET summary = $2,
+    updated_at = now()
+WHERE id = $1;
+
 -- name: GetDocumentByID :one
 SELECT *
 FROM documents
@@ -741,3 +747,133 @@ WHERE ds.state = ANY($1::processing_state[])
   AND ($4::text IS NULL OR d.filename ILIKE '%' || $4 || '%');
 
 
+
+
+-- =====================================
+-- EMBEDDING CACHE
+-- =====================================
+
+-- name: GetCachedEmbeddings :many
+SELECT cache_key, embedding
+FROM embedding_cache
+WHERE cache_key = ANY(@cache_keys::text[]);
+
+-- name: PutCachedEmbedding :exec
+INSERT INTO embedding_cache (
+    cache_key,
+    model_id,
+    input_type,
+    dimension,
+    embedding
+)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (cache_key) DO NOTHING;
+
+-- name: DeleteCachedEmbeddingsBefore :execrows
+DELETE FROM embedding_cache
+WHERE created_at < $1;
+
+
+-- =====================================
+-- AI USAGE
+-- =====================================
+
+-- name: InsertAIUsage :exec
+INSERT INTO ai_usage (
+    document_id,
+    model_id,
+    operation,
+    input_tokens,
+    output_tokens,
+    cost_usd,
+    created_at,
+    prompt_name,
+    prompt_version
+)
+VALUES (
+    NULLIF(@document_id::text, ''),
+    @model_id,
+    @operation,
+    @input_tokens,
+    @output_tokens,
+    @cost_usd,
+    @created_at,
+    NULLIF(@prompt_name::text, ''),
+    NULLIF(@prompt_version::text, '')
+);
+
+-- name: GetUsageByDocument :many
+SELECT
+    model_id,
+    operation,
+    COUNT(*) AS calls,
+    COALESCE(SUM(input_tokens), 0)::bigint AS input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS output_tokens,
+    COALESCE(SUM(cost_usd), 0)::float8 AS cost_usd
+FROM ai_usage
+WHERE document_id = @document_id::text
+GROUP BY model_id, operation
+ORDER BY model_id, operation;
+
+-- name: GetUsageByDay :many
+SELECT
+    (created_at AT TIME ZONE 'UTC')::date AS day,
+    model_id,
+    COUNT(*) AS calls,
+    COALESCE(SUM(input_tokens), 0)::bigint AS input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS output_tokens,
+    COALESCE(SUM(cost_usd), 0)::float8 AS cost_usd
+FROM ai_usage
+WHERE created_at BETWEEN @start_date AND @end_date
+GROUP BY day, model_id
+ORDER BY day, model_id;
+
+-- name: GetUsageByModel :many
+SELECT
+    model_id,
+    COUNT(*) AS calls,
+    COALESCE(SUM(input_tokens), 0)::bigint AS input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS output_tokens,
+    COALESCE(SUM(cost_usd), 0)::float8 AS cost_usd
+FROM ai_usage
+WHERE created_at BETWEEN @start_date AND @end_date
+GROUP BY model_id
+ORDER BY cost_usd DESC;
+
+-- name: ListDocumentUsage :many
+SELECT
+    document_id,
+    COUNT(*) AS calls,
+    COALESCE(SUM(input_tokens), 0)::bigint AS input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS output_tokens,
+    COALESCE(SUM(cost_usd), 0)::float8 AS cost_usd
+FROM ai_usage
+WHERE document_id IS NOT NULL
+  AND created_at BETWEEN @start_date AND @end_date
+GROUP BY document_id
+ORDER BY cost_usd DESC, document_id
+LIMIT @page_limit OFFSET @page_offset;
+
+
+-- =====================================
+-- PROMPT TEMPLATES
+-- =====================================
+
+-- name: ListPromptTemplates :many
+SELECT name, version, description, required, template, created_at
+FROM prompt_templates
+ORDER BY name, version;
+
+-- name: UpsertPromptTemplate :exec
+INSERT INTO prompt_templates (
+    name,
+    version,
+    description,
+    required,
+    template
+)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (name, version) DO UPDATE
+SET description = EXCLUDED.description,
+    required = EXCLUDED.required,
+    template = EXCLUDED.template;